@@ -2,93 +2,43 @@ package client
 
 import (
 	"context"
-	"crypto/tls"
-	"fmt"
+	"errors"
 	"log"
-	"log/slog"
 	"net/http"
 
 	"github.com/Cprime50/api-service/middleware"
 	profilepb "github.com/Cprime50/api-service/pb"
-	"github.com/Cprime50/api-service/utils"
 	"github.com/gin-gonic/gin"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	// import profile pb here
 )
 
+// ProfileClient calls profile-service over a connection owned by the
+// Registry.
 type ProfileClient struct {
 	Client profilepb.ProfileServiceClient
 }
 
-var (
-	_               = utils.LoadEnv()
-	ENV             = utils.MustHaveEnv("ENV")
-	PROFILE_SVC_URL = utils.MustHaveEnv("PROFILE_SVC_URL")
-	GRPC_PORT       = utils.MustHaveEnv("GRPC_PORT")
-	CERT_PATH       = utils.MustHaveEnv("CERT_PATH")
-	KEY_PATH        = utils.MustHaveEnv("KEY_PATH")
-)
-
 type Profile struct {
 	Username string `json:"username"`
 	Bio      string `json:"bio"`
 	Avatar   string `json:"avatar"`
 }
 
-func InitProfileServiceClient(c *context.Context) (profilepb.ProfileServiceClient, error) {
-	if ENV == "production" {
-		certificate, err := tls.LoadX509KeyPair(CERT_PATH, KEY_PATH)
-		if err != nil {
-			slog.Error("Error loading TLS certificate", "tls.LoadX509KeyPair \n", err)
-			return nil, err
-		}
-		tlsConfig := &tls.Config{
-			Certificates: []tls.Certificate{certificate},
-		}
-		creds := credentials.NewTLS(tlsConfig)
-		conn, err := grpc.DialContext(*c, PROFILE_SVC_URL, []grpc.DialOption{
-			grpc.WithTransportCredentials(creds),
-			grpc.WithBlock(),
-		}...)
-		if err != nil {
-			return nil, fmt.Errorf("connection to profile gRPC service failed: %v", err)
-		}
-		return profilepb.NewProfileServiceClient(conn), nil
-	} else {
-		// Non-production environment, use insecure connection
-		conn, err := grpc.DialContext(*c, PROFILE_SVC_URL, []grpc.DialOption{
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			grpc.WithBlock(),
-		}...)
-
-		if err != nil {
-			return nil, fmt.Errorf("connection to profile gRPC service failed: %v", err)
-		}
-		return profilepb.NewProfileServiceClient(conn), nil
-	}
-}
-
-func CreateUpdateProfile(c *gin.Context, ctx context.Context, method string) (*profilepb.Profile, error) {
+func (p *ProfileClient) CreateUpdateProfile(c *gin.Context, ctx context.Context, method string) (*profilepb.Profile, error) {
 	var profile *Profile
-	client, err := InitProfileServiceClient(&ctx)
-	if err != nil {
-		return nil, err
-	}
-
 	userValue, exists := c.Get("user")
 	if !exists {
 		log.Println("User not found in context")
 		c.AbortWithStatus(http.StatusUnauthorized)
-		return nil, err
+		return nil, errors.New("user not found in context")
 	}
 
 	user, ok := userValue.(*middleware.User)
 	if !ok || user == nil {
 		log.Println("Invalid user data in context")
 		c.AbortWithStatus(http.StatusUnauthorized)
-		return nil, err
+		return nil, errors.New("invalid user data in context")
 	}
 
 	if err := c.BindJSON(&profile); err != nil {
@@ -112,7 +62,7 @@ func CreateUpdateProfile(c *gin.Context, ctx context.Context, method string) (*p
 			Avatar:   profile.Avatar,
 		},
 	}
-	response, err := client.CreateUpdateProfile(ctx, req)
+	response, err := p.Client.CreateUpdateProfile(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -120,17 +70,33 @@ func CreateUpdateProfile(c *gin.Context, ctx context.Context, method string) (*p
 	return response, nil
 }
 
-func GetProfile(ctx context.Context, userID string) (*profilepb.Profile, error) {
-	client, err := InitProfileServiceClient(&ctx)
+// UpdateProfile sets the fields of the user's profile that paths name to
+// their values in changes, clearing those left empty. A non-zero
+// expectedVersion fails the update unless the profile is still at it.
+func (p *ProfileClient) UpdateProfile(ctx context.Context, user *middleware.User, changes *profilepb.Profile, paths []string, expectedVersion int64) (*profilepb.Profile, error) {
+	changes.UserId = user.UserID
+	changes.Email = user.Email
+	req := &profilepb.CreateUpdateProfileRequest{
+		Operation:       profilepb.Operation_UPDATE,
+		Profile:         changes,
+		UpdateMask:      &fieldmaskpb.FieldMask{Paths: paths},
+		ExpectedVersion: expectedVersion,
+	}
+
+	res, err := p.Client.CreateUpdateProfile(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
+	return res, nil
+}
+
+func (p *ProfileClient) GetProfile(ctx context.Context, userID string) (*profilepb.Profile, error) {
 	req := &profilepb.GetProfileRequest{
 		UserId: userID,
 	}
 
-	res, err := client.GetProfile(ctx, req)
+	res, err := p.Client.GetProfile(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -138,43 +104,37 @@ func GetProfile(ctx context.Context, userID string) (*profilepb.Profile, error)
 	return res, nil
 }
 
-func GetAllProfiles(ctx context.Context) ([]*profilepb.Profile, error) {
-	client, err := InitProfileServiceClient(&ctx)
+// GetAllProfiles returns the page of profiles req asks for.
+func (p *ProfileClient) GetAllProfiles(ctx context.Context, req *profilepb.GetAllProfilesRequest) (*profilepb.GetAllProfilesResponse, error) {
+	res, err := p.Client.GetAllProfiles(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	req := &profilepb.Empty{}
+	return res, nil
+}
 
-	stream, err := client.GetAllProfiles(ctx, req)
-	if err != nil {
-		return nil, err
+// CheckUsernameAvailable tells whether username can be chosen, with why
+// not and a free suggestion when it cannot.
+func (p *ProfileClient) CheckUsernameAvailable(ctx context.Context, username string) (*profilepb.CheckUsernameAvailableResponse, error) {
+	req := &profilepb.CheckUsernameAvailableRequest{
+		Username: username,
 	}
 
-	// Read profiles from stream
-	var profiles []*profilepb.Profile
-	for {
-		profile, err := stream.Recv()
-		if err != nil {
-			break
-		}
-		profiles = append(profiles, profile)
+	res, err := p.Client.CheckUsernameAvailable(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	return profiles, nil
+	return res, nil
 }
 
-func DeleteProfile(ctx context.Context, userID string) error {
-	client, err := InitProfileServiceClient(&ctx)
-	if err != nil {
-		return err
-	}
-
+func (p *ProfileClient) DeleteProfile(ctx context.Context, userID string) error {
 	req := &profilepb.DeleteProfileRequest{
 		UserId: userID,
 	}
 
-	_, err = client.DeleteProfile(ctx, req)
+	_, err := p.Client.DeleteProfile(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -183,21 +143,30 @@ func DeleteProfile(ctx context.Context, userID string) error {
 }
 
 // UpdateScore updates the score for the given user ID.
-func UpdateScore(ctx context.Context, userID string, score int64) error {
-	client, err := InitProfileServiceClient(&ctx)
-	if err != nil {
-		return err
-	}
-
+func (p *ProfileClient) UpdateScore(ctx context.Context, userID string, score int64) error {
 	req := &profilepb.UpdateScoreRequest{
 		UserId: userID,
 		Score:  score,
 	}
 
-	_, err = client.UpdateScore(ctx, req)
+	_, err := p.Client.UpdateScore(ctx, req)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// ExportMyData returns the profile of a user with their score history.
+func (p *ProfileClient) ExportMyData(ctx context.Context, userID string) (*profilepb.ProfileExport, error) {
+	req := &profilepb.ExportMyDataRequest{
+		UserId: userID,
+	}
+
+	res, err := p.Client.ExportMyData(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
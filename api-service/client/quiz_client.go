@@ -1 +1,455 @@
 package client
+
+import (
+	"context"
+	"io"
+
+	quizpb "github.com/Cprime50/api-service/quizpb"
+)
+
+// importChunkSize is the size of the chunks files are uploaded in.
+const importChunkSize = 64 << 10
+
+// QuizClient calls quiz-service over a connection owned by the Registry.
+type QuizClient struct {
+	Client quizpb.QuizServiceClient
+}
+
+type Quiz struct {
+	Id        int64  `json:"id"`
+	Japanese  string `json:"japanese"`
+	Pronounce string `json:"pronounce"`
+	English   string `json:"english"`
+}
+
+// QuizFilter narrows quizzes down to a deck and a tag, the zero value
+// matches every quiz.
+type QuizFilter struct {
+	DeckId int64
+	Tag    string
+}
+
+type Deck struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type QuizAnswer struct {
+	Id     int64  `json:"id"`
+	Answer string `json:"answer"`
+}
+
+type QuizResult struct {
+	SessionId string       `json:"session_id"`
+	Answers   []QuizAnswer `json:"answers"`
+}
+
+// GetQuiz starts a quiz session on the quizzes matching filter. format
+// chooses whether the learner picks or types the answers, direction what they
+// are shown and asked for.
+func (q *QuizClient) GetQuiz(ctx context.Context, userID string, seed int64, mode quizpb.QuizMode, format quizpb.AnswerFormat, direction quizpb.Direction, filter QuizFilter) (*quizpb.GetQuizResponse, error) {
+	req := &quizpb.GetQuizRequest{
+		UserId:    userID,
+		Seed:      seed,
+		Mode:      mode,
+		Format:    format,
+		Direction: direction,
+		DeckId:    filter.DeckId,
+		Tag:       filter.Tag,
+	}
+
+	res, err := q.Client.GetQuiz(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetResult grades the answers of a quiz session for the given user.
+func (q *QuizClient) GetResult(ctx context.Context, userID, username string, result *QuizResult) (*quizpb.GetResultResponse, error) {
+	req := &quizpb.GetResultRequest{
+		UserId:    userID,
+		Username:  username,
+		SessionId: result.SessionId,
+	}
+	for _, answer := range result.Answers {
+		req.Quizes = append(req.Quizes, &quizpb.Quiz{Id: answer.Id})
+		req.Answer = append(req.Answer, answer.Answer)
+	}
+
+	res, err := q.Client.GetResult(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (q *QuizClient) GetScore(ctx context.Context, userID string) (*quizpb.GetScoreResponse, error) {
+	req := &quizpb.GetScoreRequest{
+		UserId: userID,
+	}
+
+	res, err := q.Client.GetScore(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetLeaderBoard returns the top limit learners of the window, followed by
+// userID's own entry when they are not among them.
+func (q *QuizClient) GetLeaderBoard(ctx context.Context, userID string, window quizpb.LeaderBoardWindow, limit int32) ([]*quizpb.LeaderBoard, error) {
+	req := &quizpb.GetLeaderBoardRequest{
+		UserId: userID,
+		Window: window,
+		Limit:  limit,
+	}
+
+	stream, err := q.Client.GetLeaderBoard(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read leaderboard from stream
+	var leaderBoard []*quizpb.LeaderBoard
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		leaderBoard = append(leaderBoard, entry)
+	}
+
+	return leaderBoard, nil
+}
+
+// CreateUpdateQuiz creates the quizzes without an id and updates the others,
+// authorID is saved in their revision history.
+func (q *QuizClient) CreateUpdateQuiz(ctx context.Context, authorID string, quizzes []Quiz) error {
+	req := &quizpb.CreateUpdateQuizRequest{AuthorId: authorID}
+	for _, quiz := range quizzes {
+		req.Quizes = append(req.Quizes, &quizpb.Quiz{
+			Id:        quiz.Id,
+			Japanese:  quiz.Japanese,
+			Pronounce: quiz.Pronounce,
+			English:   quiz.English,
+		})
+	}
+
+	_, err := q.Client.CreateUpdateQuiz(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteQuiz moves the quizzes to the trash.
+func (q *QuizClient) DeleteQuiz(ctx context.Context, authorID string, quizIDs []int64) error {
+	req := &quizpb.DeleteQuizRequest{
+		QuizId:   quizIDs,
+		AuthorId: authorID,
+	}
+
+	_, err := q.Client.DeleteQuiz(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListTrash returns the deleted quizzes, last deleted first.
+func (q *QuizClient) ListTrash(ctx context.Context) ([]*quizpb.Quiz, error) {
+	stream, err := q.Client.ListTrash(ctx, &quizpb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var quizzes []*quizpb.Quiz
+	for {
+		quiz, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		quizzes = append(quizzes, quiz)
+	}
+
+	return quizzes, nil
+}
+
+func (q *QuizClient) RestoreQuiz(ctx context.Context, authorID string, quizIDs []int64) error {
+	req := &quizpb.RestoreQuizRequest{
+		QuizId:   quizIDs,
+		AuthorId: authorID,
+	}
+
+	_, err := q.Client.RestoreQuiz(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PurgeTrash deletes the quizzes past the trash retention period for good.
+func (q *QuizClient) PurgeTrash(ctx context.Context) (*quizpb.PurgeTrashResponse, error) {
+	res, err := q.Client.PurgeTrash(ctx, &quizpb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAllQuizzes returns the quizzes matching filter with their decks and tags.
+func (q *QuizClient) GetAllQuizzes(ctx context.Context, filter QuizFilter) ([]*quizpb.Quiz, error) {
+	req := &quizpb.GetAllQuizzesRequest{
+		DeckId: filter.DeckId,
+		Tag:    filter.Tag,
+	}
+
+	stream, err := q.Client.GetAllQuizzes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read quizzes from stream
+	var quizzes []*quizpb.Quiz
+	for {
+		quiz, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		quizzes = append(quizzes, quiz)
+	}
+
+	return quizzes, nil
+}
+
+// CreateUpdateDeck creates the deck if it has no id and updates it otherwise.
+func (q *QuizClient) CreateUpdateDeck(ctx context.Context, deck Deck) (*quizpb.Deck, error) {
+	req := &quizpb.Deck{
+		Id:          deck.Id,
+		Name:        deck.Name,
+		Description: deck.Description,
+	}
+
+	res, err := q.Client.CreateUpdateDeck(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (q *QuizClient) DeleteDeck(ctx context.Context, deckID int64) error {
+	req := &quizpb.DeleteDeckRequest{
+		DeckId: deckID,
+	}
+
+	_, err := q.Client.DeleteDeck(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q *QuizClient) ListDecks(ctx context.Context) ([]*quizpb.Deck, error) {
+	stream, err := q.Client.ListDecks(ctx, &quizpb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var decks []*quizpb.Deck
+	for {
+		deck, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		decks = append(decks, deck)
+	}
+
+	return decks, nil
+}
+
+// AssignDeck adds the quizzes to the deck, or takes them out of it when
+// remove is set.
+func (q *QuizClient) AssignDeck(ctx context.Context, deckID int64, quizIDs []int64, remove bool) error {
+	req := &quizpb.AssignDeckRequest{
+		DeckId: deckID,
+		QuizId: quizIDs,
+		Remove: remove,
+	}
+
+	_, err := q.Client.AssignDeck(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (q *QuizClient) ListTags(ctx context.Context) ([]*quizpb.Tag, error) {
+	stream, err := q.Client.ListTags(ctx, &quizpb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []*quizpb.Tag
+	for {
+		tag, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// AssignTags tags the quizzes, or untags them when remove is set.
+func (q *QuizClient) AssignTags(ctx context.Context, quizIDs []int64, tags []string, remove bool) error {
+	req := &quizpb.AssignTagsRequest{
+		QuizId: quizIDs,
+		Tags:   tags,
+		Remove: remove,
+	}
+
+	_, err := q.Client.AssignTags(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ImportQuizzes uploads a quiz file in format and returns how many of its
+// rows were imported and why the others were skipped.
+func (q *QuizClient) ImportQuizzes(ctx context.Context, authorID string, format quizpb.FileFormat, file io.Reader) (*quizpb.ImportQuizzesResponse, error) {
+	stream, err := q.Client.ImportQuizzes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk := make([]byte, importChunkSize)
+	for {
+		n, err := file.Read(chunk)
+		if n > 0 {
+			sendErr := stream.Send(&quizpb.ImportQuizzesRequest{Format: format, Chunk: chunk[:n], AuthorId: authorID})
+			// io.EOF means the server stopped reading, CloseAndRecv returns why
+			if sendErr == io.EOF {
+				break
+			}
+			if sendErr != nil {
+				return nil, sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// ExportQuizzes writes the quizzes matching filter to w as a file in format.
+func (q *QuizClient) ExportQuizzes(ctx context.Context, format quizpb.FileFormat, filter QuizFilter, w io.Writer) error {
+	req := &quizpb.ExportQuizzesRequest{
+		Format: format,
+		DeckId: filter.DeckId,
+		Tag:    filter.Tag,
+	}
+
+	stream, err := q.Client.ExportQuizzes(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.Chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// GetQuizHistory returns the revisions of a quiz, latest first.
+func (q *QuizClient) GetQuizHistory(ctx context.Context, quizID int64) ([]*quizpb.QuizRevision, error) {
+	stream, err := q.Client.GetQuizHistory(ctx, &quizpb.GetQuizHistoryRequest{QuizId: quizID})
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []*quizpb.QuizRevision
+	for {
+		rev, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, nil
+}
+
+// RevertQuiz gives a quiz back its content as of the revision.
+func (q *QuizClient) RevertQuiz(ctx context.Context, authorID string, revisionID int64) (*quizpb.Quiz, error) {
+	req := &quizpb.RevertQuizRequest{
+		RevisionId: revisionID,
+		AuthorId:   authorID,
+	}
+
+	res, err := q.Client.RevertQuiz(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ExportMyData returns the quiz attempts and review states of a learner.
+func (q *QuizClient) ExportMyData(ctx context.Context, userID string) (*quizpb.QuizExport, error) {
+	req := &quizpb.ExportMyDataRequest{
+		UserId: userID,
+	}
+
+	res, err := q.Client.ExportMyData(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,122 @@
+package client
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	profilepb "github.com/Cprime50/api-service/pb"
+	quizpb "github.com/Cprime50/api-service/quizpb"
+	"github.com/Cprime50/api-service/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// keepaliveParams pings idle connections so a backend that went away is
+// noticed before the next request is sent on it. The backends allow pings
+// this often, see their keepalive enforcement policy.
+var keepaliveParams = keepalive.ClientParameters{
+	Time:                30 * time.Second,
+	Timeout:             10 * time.Second,
+	PermitWithoutStream: true,
+}
+
+// retryServiceConfig retries calls rejected with UNAVAILABLE, which the
+// backend returns before it starts handling them, so retrying is safe for
+// every method.
+const retryServiceConfig = `{
+	"methodConfig": [{
+		"name": [{"service": %q}],
+		"retryPolicy": {
+			"maxAttempts": 3,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "1s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`
+
+// Registry holds one long-lived connection per backend service. It is
+// created once at startup, shared by every request and closed on shutdown.
+type Registry struct {
+	Profile *ProfileClient
+	Quiz    *QuizClient
+
+	conns []*grpc.ClientConn
+}
+
+// NewRegistry connects to profile-service and quiz-service at
+// PROFILE_SVC_URL and QUIZ_SVC_URL. Connections are established lazily, so
+// the api service can start before the backends are up.
+func NewRegistry() (*Registry, error) {
+	creds, err := transportCredentials(utils.MustHaveEnv("ENV"))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Registry{}
+	profileConn, err := r.dial(utils.MustHaveEnv("PROFILE_SVC_URL"), profilepb.ProfileService_ServiceDesc.ServiceName, creds)
+	if err != nil {
+		return nil, fmt.Errorf("connection to profile gRPC service failed: %v", err)
+	}
+	quizConn, err := r.dial(utils.MustHaveEnv("QUIZ_SVC_URL"), quizpb.QuizService_ServiceDesc.ServiceName, creds)
+	if err != nil {
+		r.Close()
+		return nil, fmt.Errorf("connection to quiz gRPC service failed: %v", err)
+	}
+
+	r.Profile = &ProfileClient{Client: profilepb.NewProfileServiceClient(profileConn)}
+	r.Quiz = &QuizClient{Client: quizpb.NewQuizServiceClient(quizConn)}
+	return r, nil
+}
+
+func (r *Registry) dial(url, service string, creds credentials.TransportCredentials) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(url,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithKeepaliveParams(keepaliveParams),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: 5 * time.Second,
+		}),
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(retryServiceConfig, service)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r.conns = append(r.conns, conn)
+	return conn, nil
+}
+
+// Close closes every backend connection. Calls in flight fail with
+// CANCELED.
+func (r *Registry) Close() error {
+	var errs []error
+	for _, conn := range r.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	r.conns = nil
+	return errors.Join(errs...)
+}
+
+func transportCredentials(env string) (credentials.TransportCredentials, error) {
+	if env != "production" {
+		// Non-production environment, use insecure connection
+		return insecure.NewCredentials(), nil
+	}
+	certificate, err := tls.LoadX509KeyPair(utils.MustHaveEnv("CERT_PATH"), utils.MustHaveEnv("KEY_PATH"))
+	if err != nil {
+		slog.Error("Error loading TLS certificate", "tls.LoadX509KeyPair \n", err)
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+	}), nil
+}
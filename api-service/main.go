@@ -1,53 +1,103 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/Cprime50/api-service/client"
 	"github.com/Cprime50/api-service/middleware"
 	routes "github.com/Cprime50/api-service/routes"
+	"github.com/Cprime50/api-service/storage"
+	"github.com/Cprime50/api-service/utils"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	// shutdownTimeout is how long requests in flight get to finish once the
+	// service is asked to stop.
+	shutdownTimeout = 10 * time.Second
+	// uploadsPath is where files kept in the local blob store are served.
+	uploadsPath = "/uploads"
+)
+
 func main() {
+	utils.LoadEnv()
 
-	// Serve static html file to test firebase auth in the browser
-	http.Handle("/", http.FileServer(http.Dir(".")))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	go func() {
-		// Serve Gin server
-		r := gin.Default()
-		r.Use(cors.Default())
-		client, err := middleware.InitAuth()
-		if err != nil {
-			log.Println(err)
-			return
-		}
+	// Connect backend services, shared by every request
+	clients, err := client.NewRegistry()
+	if err != nil {
+		log.Fatal("Error connecting to backend services", err)
+	}
+	defer clients.Close()
 
-		// Sets your email as admin on firebase
-		//middleware.SetDefaultFirebaseAdmin(context.Background(), client)
+	authenticator, err := middleware.InitAuth()
+	if err != nil {
+		log.Println(err)
+		return
+	}
 
-		routes.RegisterProfileRoutes(r, client)
-		routes.RegisterAdminRoutes(r, client)
+	// Serve Gin server
+	r := gin.Default()
+	r.Use(cors.Default())
 
-		// Set port
-		port := os.Getenv("PORT")
-		if port == "" {
-			port = "localhost:8080" // Default port
-		}
+	// Sets your email as admin on firebase
+	//middleware.SetDefaultFirebaseAdmin(context.Background(), authenticator)
+
+	// Keep uploads such as avatars on the local filesystem
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "uploads" // Default directory
+	}
+	r.Static(uploadsPath, uploadDir)
+
+	h := routes.NewHandler(ctx, clients, storage.NewLocalStore(uploadDir, uploadsPath))
+	routes.RegisterAuthRoutes(r, authenticator)
+	routes.RegisterProfileRoutes(r, authenticator, h)
+	routes.RegisterAdminRoutes(r, authenticator, h)
+	routes.RegisterQuizRoutes(r, authenticator, h)
 
-		// Start server
+	// Set port
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "localhost:8080" // Default port
+	}
+	ginServer := &http.Server{Addr: port, Handler: r}
+
+	// Serve static html file to test firebase auth in the browser
+	staticServer := &http.Server{Addr: ":8000", Handler: http.FileServer(http.Dir("."))}
+
+	go func() {
 		log.Printf("Gin server is running on port %s", port)
-		if err := r.Run(port); err != nil {
+		if err := ginServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start Gin server: %v", err)
 		}
 	}()
+	go func() {
+		log.Println("Static file server is running on port 8000")
+		if err := staticServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start static file server: %v", err)
+		}
+	}()
 
-	// Start static html server
-	log.Println("Static file server is running on port 8000")
-	if err := http.ListenAndServe(":8000", nil); err != nil {
-		log.Fatalf("Failed to start static file server: %v", err)
+	<-ctx.Done()
+	log.Println("Shutting down")
+
+	// Let requests in flight finish before the backend connections close
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := ginServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down Gin server: %v", err)
+	}
+	if err := staticServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down static file server: %v", err)
 	}
 }
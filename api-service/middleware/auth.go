@@ -9,11 +9,7 @@ import (
 	"strings"
 	"time"
 
-	firebase "firebase.google.com/go/v4"
-	"firebase.google.com/go/v4/auth"
-
 	"github.com/gin-gonic/gin"
-	"google.golang.org/api/option"
 )
 
 type User struct {
@@ -24,7 +20,13 @@ type User struct {
 
 var ()
 
-func Auth(client *auth.Client) gin.HandlerFunc {
+// SameEmail reports whether a and b are the same email address, ignoring
+// case and surrounding spaces as identity providers do.
+func SameEmail(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+func Auth(authenticator Authenticator) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		startTime := time.Now()
 		adminEmail := os.Getenv("ADMIN_EMAIL")
@@ -41,41 +43,40 @@ func Auth(client *auth.Client) gin.HandlerFunc {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
-		token, err := client.VerifyIDToken(context.Background(), idToken[1])
+		token, err := authenticator.VerifyIDToken(context.Background(), idToken[1])
 		if err != nil {
 			log.Printf("Error verifying token. Error: %v\n", err)
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
-		email, ok := token.Claims["email"].(string)
-		if !ok {
+		if token.Email == "" {
 			log.Println("Email claim not found in token")
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
-		role, ok := token.Claims["role"].(string)
-		if !ok {
-			if email == adminEmail {
-				if err := MakeAdmin(ctx, client, adminEmail); err != nil {
+		role := token.Role
+		if role == "" {
+			if adminEmail != "" && SameEmail(token.Email, adminEmail) {
+				if err := MakeAdmin(ctx, authenticator, token.Email); err != nil {
 					log.Printf("Error making adminEmail admin: %v\n", err)
 					ctx.AbortWithStatus(http.StatusInternalServerError)
 					return
 				}
-				role = "admin"
+				role = RoleAdmin
 			} else {
-				if err := MakeUser(ctx, client, token.UID); err != nil {
+				if err := MakeUser(ctx, authenticator, token.UID); err != nil {
 					log.Printf("Error making user regular user: %v\n", err)
 					ctx.AbortWithStatus(http.StatusInternalServerError)
 					return
 				}
-				role = "user"
+				role = RoleUser
 			}
 		}
 
 		user := &User{
 			UserID: token.UID,
-			Email:  token.Claims["email"].(string),
+			Email:  token.Email,
 			Role:   role,
 		}
 
@@ -90,21 +91,3 @@ func Auth(client *auth.Client) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
-
-func InitAuth() (*auth.Client, error) {
-	var firebaseCredFile = os.Getenv("FIREBASE_KEY")
-	opt := option.WithCredentialsFile(firebaseCredFile)
-	app, err := firebase.NewApp(context.Background(), nil, opt)
-	if err != nil {
-		log.Fatalf("error initializing firebase app: %v", err)
-		return nil, err
-	}
-
-	client, errAuth := app.Auth(context.Background())
-	if errAuth != nil {
-		log.Fatalf("error initializing firebase auth: %v", errAuth)
-		return nil, errAuth
-	}
-
-	return client, nil
-}
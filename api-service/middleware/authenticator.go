@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+// Authenticator verifies ID tokens and manages the role claim of users for
+// one identity provider.
+type Authenticator interface {
+	// VerifyIDToken checks the token and returns the user it was issued to.
+	// Role is empty when the user has not been given one yet.
+	VerifyIDToken(ctx context.Context, idToken string) (*AuthUser, error)
+	GetUser(ctx context.Context, uid string) (*AuthUser, error)
+	GetUserByEmail(ctx context.Context, email string) (*AuthUser, error)
+	// SetRole stores role in the claims of the user's future tokens.
+	SetRole(ctx context.Context, uid, role string) error
+}
+
+// AuthUser is a user as known by the identity provider.
+type AuthUser struct {
+	UID   string
+	Email string
+	Role  string
+}
+
+// InitAuth returns the Authenticator selected by AUTH_PROVIDER, "firebase"
+// by default or "local" to issue and verify tokens without any external
+// service. The local provider signs a token for any email, so it is refused
+// in production. AUTH_LOCAL_ADMIN_EMAIL names a user the local provider
+// makes admin, ADMIN_EMAIL is only promoted through a real provider.
+func InitAuth() (Authenticator, error) {
+	switch provider := os.Getenv("AUTH_PROVIDER"); provider {
+	case "", "firebase":
+		return NewFirebaseAuth(os.Getenv("FIREBASE_KEY"))
+	case "local":
+		if os.Getenv("ENV") == "production" {
+			return nil, errors.New("AUTH_PROVIDER local is for development and tests, it cannot be used in production")
+		}
+		local, err := NewLocalAuth(os.Getenv("AUTH_JWT_ALG"), os.Getenv("AUTH_JWT_SECRET"), os.Getenv("AUTH_JWT_KEY"))
+		if err != nil {
+			return nil, err
+		}
+		if email := os.Getenv("AUTH_LOCAL_ADMIN_EMAIL"); email != "" {
+			local.SeedRole(email, RoleAdmin)
+		}
+		return local, nil
+	default:
+		return nil, fmt.Errorf("unknown AUTH_PROVIDER %q", provider)
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInitAuthLocal(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		wantErr bool
+	}{
+		{"development", "development", false},
+		{"test", "test", false},
+		{"production", "production", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AUTH_PROVIDER", "local")
+			t.Setenv("AUTH_JWT_ALG", "HS256")
+			t.Setenv("AUTH_JWT_SECRET", "secret")
+			t.Setenv("ENV", tt.env)
+
+			authenticator, err := InitAuth()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InitAuth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if _, ok := authenticator.(*LocalAuth); !ok {
+					t.Errorf("InitAuth() = %T, want *LocalAuth", authenticator)
+				}
+			}
+		})
+	}
+}
+
+func TestInitAuthLocalAdmin(t *testing.T) {
+	t.Setenv("AUTH_PROVIDER", "local")
+	t.Setenv("AUTH_JWT_ALG", "HS256")
+	t.Setenv("AUTH_JWT_SECRET", "secret")
+	t.Setenv("ENV", "development")
+	t.Setenv("AUTH_LOCAL_ADMIN_EMAIL", " Admin@Example.com")
+
+	authenticator, err := InitAuth()
+	if err != nil {
+		t.Fatalf("InitAuth() error = %v", err)
+	}
+	local := authenticator.(*LocalAuth)
+	tests := []struct {
+		email string
+		role  string
+	}{
+		{"admin@example.com", RoleAdmin},
+		{"ADMIN@example.com ", RoleAdmin},
+		{"learner@example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			token, err := local.IssueToken(tt.email)
+			if err != nil {
+				t.Fatalf("IssueToken() error = %v", err)
+			}
+			user, err := local.VerifyIDToken(context.Background(), token)
+			if err != nil {
+				t.Fatalf("VerifyIDToken() error = %v", err)
+			}
+			if user.Role != tt.role {
+				t.Errorf("role = %q, want %q", user.Role, tt.role)
+			}
+		})
+	}
+}
+
+func TestSameEmail(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"admin@example.com", "admin@example.com", true},
+		{"Admin@Example.com", "admin@example.com", true},
+		{" admin@example.com\n", "admin@example.com ", true},
+		{"admin@example.com", "other@example.com", false},
+		{"", "admin@example.com", false},
+	}
+	for _, tt := range tests {
+		if got := SameEmail(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameEmail(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"log"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/api/option"
+)
+
+// FirebaseAuth verifies Firebase ID tokens and keeps roles in the custom
+// claims of the Firebase user.
+type FirebaseAuth struct {
+	client *auth.Client
+}
+
+func NewFirebaseAuth(credFile string) (*FirebaseAuth, error) {
+	opt := option.WithCredentialsFile(credFile)
+	app, err := firebase.NewApp(context.Background(), nil, opt)
+	if err != nil {
+		log.Printf("error initializing firebase app: %v", err)
+		return nil, err
+	}
+
+	client, err := app.Auth(context.Background())
+	if err != nil {
+		log.Printf("error initializing firebase auth: %v", err)
+		return nil, err
+	}
+
+	return &FirebaseAuth{client: client}, nil
+}
+
+func (f *FirebaseAuth) VerifyIDToken(ctx context.Context, idToken string) (*AuthUser, error) {
+	token, err := f.client.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	email, _ := token.Claims["email"].(string)
+	role, _ := token.Claims["role"].(string)
+	return &AuthUser{UID: token.UID, Email: email, Role: role}, nil
+}
+
+func (f *FirebaseAuth) GetUser(ctx context.Context, uid string) (*AuthUser, error) {
+	user, err := f.client.GetUser(ctx, uid)
+	if err != nil {
+		if auth.IsUserNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return firebaseUser(user), nil
+}
+
+func (f *FirebaseAuth) GetUserByEmail(ctx context.Context, email string) (*AuthUser, error) {
+	user, err := f.client.GetUserByEmail(ctx, email)
+	if err != nil {
+		if auth.IsUserNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return firebaseUser(user), nil
+}
+
+// SetRole keeps the other custom claims of the user. The "admin" claim is
+// kept in sync with the role for clients that still read it.
+func (f *FirebaseAuth) SetRole(ctx context.Context, uid, role string) error {
+	user, err := f.client.GetUser(ctx, uid)
+	if err != nil {
+		if auth.IsUserNotFound(err) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	currentCustomClaims := user.CustomClaims
+	if currentCustomClaims == nil {
+		currentCustomClaims = map[string]interface{}{}
+	}
+	currentCustomClaims["role"] = role
+	if role == RoleAdmin {
+		currentCustomClaims["admin"] = true
+	} else {
+		delete(currentCustomClaims, "admin")
+	}
+
+	return f.client.SetCustomUserClaims(ctx, uid, currentCustomClaims)
+}
+
+func firebaseUser(user *auth.UserRecord) *AuthUser {
+	role, _ := user.CustomClaims["role"].(string)
+	return &AuthUser{UID: user.UID, Email: user.Email, Role: role}
+}
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	localIssuer   = "shiken-go"
+	localTokenTTL = time.Hour
+)
+
+// LocalAuth issues and verifies its own JWTs, so the stack can run and be
+// tested without Firebase. Roles are kept in memory and carried in the
+// tokens, a restart keeps the role of any user whose token is still valid.
+type LocalAuth struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+
+	mu    sync.RWMutex
+	users map[string]*AuthUser
+}
+
+type localClaims struct {
+	Email string `json:"email"`
+	Role  string `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// NewLocalAuth signs tokens with HS256 and secret, or with RS256 and the PEM
+// private key at keyPath. Without a key path a new RSA key is generated and
+// tokens do not survive a restart.
+func NewLocalAuth(alg, secret, keyPath string) (*LocalAuth, error) {
+	a := &LocalAuth{users: map[string]*AuthUser{}}
+
+	switch alg {
+	case "", "HS256":
+		if secret == "" {
+			return nil, errors.New("AUTH_JWT_SECRET is required for HS256")
+		}
+		a.method = jwt.SigningMethodHS256
+		a.signKey = []byte(secret)
+		a.verifyKey = []byte(secret)
+	case "RS256":
+		var key *rsa.PrivateKey
+		if keyPath == "" {
+			log.Println("AUTH_JWT_KEY not set, generating a temporary RSA key")
+			generated, err := rsa.GenerateKey(rand.Reader, 2048)
+			if err != nil {
+				return nil, fmt.Errorf("rsa.GenerateKey: %w", err)
+			}
+			key = generated
+		} else {
+			pem, err := os.ReadFile(keyPath)
+			if err != nil {
+				return nil, fmt.Errorf("reading AUTH_JWT_KEY: %w", err)
+			}
+			key, err = jwt.ParseRSAPrivateKeyFromPEM(pem)
+			if err != nil {
+				return nil, fmt.Errorf("parsing AUTH_JWT_KEY: %w", err)
+			}
+		}
+		a.method = jwt.SigningMethodRS256
+		a.signKey = key
+		a.verifyKey = &key.PublicKey
+	default:
+		return nil, fmt.Errorf("unsupported AUTH_JWT_ALG %q", alg)
+	}
+
+	return a, nil
+}
+
+// localUser returns the normalized email and the user id derived from it,
+// which is stable across restarts.
+func localUser(email string) (string, string) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	sum := sha256.Sum256([]byte(email))
+	return email, hex.EncodeToString(sum[:14])
+}
+
+// SeedRole registers the user with email and role before they first sign
+// in, so a stack running without Firebase can have an admin to grant the
+// other roles.
+func (a *LocalAuth) SeedRole(email, role string) {
+	email, uid := localUser(email)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.users[uid] = &AuthUser{UID: uid, Email: email, Role: role}
+}
+
+// IssueToken returns a token for email, registering the user on first use.
+func (a *LocalAuth) IssueToken(email string) (string, error) {
+	email, uid := localUser(email)
+
+	a.mu.Lock()
+	user, ok := a.users[uid]
+	if !ok {
+		user = &AuthUser{UID: uid, Email: email}
+		a.users[uid] = user
+	}
+	role := user.Role
+	a.mu.Unlock()
+
+	now := time.Now()
+	claims := localClaims{
+		Email: email,
+		Role:  role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    localIssuer,
+			Subject:   uid,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(localTokenTTL)),
+		},
+	}
+	return jwt.NewWithClaims(a.method, claims).SignedString(a.signKey)
+}
+
+func (a *LocalAuth) VerifyIDToken(ctx context.Context, idToken string) (*AuthUser, error) {
+	claims := &localClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method.Alg() != a.method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s", t.Method.Alg())
+		}
+		return a.verifyKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !claims.VerifyIssuer(localIssuer, true) || claims.Subject == "" {
+		return nil, errors.New("invalid token claims")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	user, ok := a.users[claims.Subject]
+	if !ok {
+		// Issued before a restart
+		user = &AuthUser{UID: claims.Subject, Email: claims.Email, Role: claims.Role}
+		a.users[claims.Subject] = user
+	}
+	verified := *user
+	return &verified, nil
+}
+
+func (a *LocalAuth) GetUser(ctx context.Context, uid string) (*AuthUser, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	user, ok := a.users[uid]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+	found := *user
+	return &found, nil
+}
+
+func (a *LocalAuth) GetUserByEmail(ctx context.Context, email string) (*AuthUser, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, user := range a.users {
+		if user.Email == email {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+// SetRole applies at once, tokens issued before keep their old role claim
+// but the role of a known user is read from memory.
+func (a *LocalAuth) SetRole(ctx context.Context, uid, role string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	user, ok := a.users[uid]
+	if !ok {
+		return ErrUserNotFound
+	}
+	user.Role = role
+	return nil
+}
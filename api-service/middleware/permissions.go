@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrUnknownRole = errors.New("unknown role")
+
+// Permission is one action a role can be allowed to take.
+type Permission string
+
+const (
+	// PermQuizWrite allows creating, updating and deleting quizzes.
+	PermQuizWrite Permission = "quiz:write"
+	// PermQuizReview allows listing every quiz with its answer.
+	PermQuizReview Permission = "quiz:review"
+	// PermProfileReadAny allows reading the profile of any user.
+	PermProfileReadAny Permission = "profile:read:any"
+	// PermProfileDeleteAny allows deleting the profile of any user.
+	PermProfileDeleteAny Permission = "profile:delete:any"
+	// PermAdminGrant allows assigning roles.
+	PermAdminGrant Permission = "admin:grant"
+)
+
+const (
+	RoleAdmin         = "admin"
+	RoleModerator     = "moderator"
+	RoleContentEditor = "content-editor"
+	RoleUser          = "user"
+)
+
+// rolePermissions maps every role to what it may do on top of what every
+// signed in user can do with their own data.
+var rolePermissions = map[string][]Permission{
+	RoleAdmin: {
+		PermQuizWrite,
+		PermQuizReview,
+		PermProfileReadAny,
+		PermProfileDeleteAny,
+		PermAdminGrant,
+	},
+	RoleModerator: {
+		PermQuizReview,
+		PermProfileReadAny,
+		PermProfileDeleteAny,
+	},
+	RoleContentEditor: {
+		PermQuizWrite,
+		PermQuizReview,
+	},
+	RoleUser: {},
+}
+
+// HasPermission reports whether role grants permission. Unknown roles grant
+// nothing.
+func HasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRole reports whether role is one of the known roles.
+func IsRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Roles returns the permissions of every role, keyed by role name.
+func Roles() map[string][]Permission {
+	roles := make(map[string][]Permission, len(rolePermissions))
+	for role, permissions := range rolePermissions {
+		sorted := append([]Permission{}, permissions...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		roles[role] = sorted
+	}
+	return roles
+}
+
+// AssignRole gives role to the user with email.
+func AssignRole(ctx context.Context, authenticator Authenticator, email, role string) error {
+	if !IsRole(role) {
+		return ErrUnknownRole
+	}
+	user, err := authenticator.GetUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("AssignRole Error: User with email %s not found: %w", email, err)
+	}
+
+	if err := authenticator.SetRole(ctx, user.UID, role); err != nil {
+		return fmt.Errorf("AssignRole Error: Error setting custom claims %w", err)
+	}
+
+	return nil
+}
@@ -6,11 +6,12 @@ import (
 	"log"
 	"net/http"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/gin-gonic/gin"
 )
 
-func RoleAuth(requiredRole string) gin.HandlerFunc {
+// RequirePermission lets the request through only if the role of the
+// authenticated user grants permission. It must run after Auth.
+func RequirePermission(permission Permission) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		userValue, exists := ctx.Get("user")
 		if !exists {
@@ -32,85 +33,47 @@ func RoleAuth(requiredRole string) gin.HandlerFunc {
 			return
 		}
 
-		if user.Role != requiredRole {
-			log.Printf("User with email %s and role %s tried to access a route that was for the %s role only",
-				user.Email, user.Role, requiredRole)
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+		if !HasPermission(user.Role, permission) {
+			log.Printf("User with email %s and role %s tried to access a route that requires the %s permission",
+				user.Email, user.Role, permission)
+			ctx.AbortWithStatus(http.StatusForbidden)
 			return
 		}
 
-		log.Printf("User with email %s and role %s authorized", user.Email, user.Role)
+		log.Printf("User with email %s and role %s authorized for %s", user.Email, user.Role, permission)
 		ctx.Next()
 	}
 }
 
-func MakeAdmin(ctx context.Context, client *auth.Client, email string) error {
-	user, err := client.GetUserByEmail(ctx, email)
+func MakeAdmin(ctx context.Context, authenticator Authenticator, email string) error {
+	user, err := authenticator.GetUserByEmail(ctx, email)
 	if err != nil {
-		return err
-	}
-	if user == nil {
-		return fmt.Errorf("MakeAdmin Error: User with email %s not found", email)
-	}
-
-	currentCustomClaims := user.CustomClaims
-	if currentCustomClaims == nil {
-		currentCustomClaims = map[string]interface{}{}
+		return fmt.Errorf("MakeAdmin Error: User with email %s not found: %w", email, err)
 	}
-	currentCustomClaims["role"] = "admin" // Set the user role as admin
 
-	if _, found := currentCustomClaims["admin"]; !found {
-		currentCustomClaims["admin"] = true
-	}
-
-	if err := client.SetCustomUserClaims(ctx, user.UID, currentCustomClaims); err != nil {
+	if err := authenticator.SetRole(ctx, user.UID, RoleAdmin); err != nil {
 		return fmt.Errorf("MakeAdmin Error: Error setting custom claims %w", err)
 	}
 
 	return nil
 }
 
-func RemoveAdmin(ctx context.Context, client *auth.Client, email string) error {
-	user, err := client.GetUserByEmail(ctx, email)
+func RemoveAdmin(ctx context.Context, authenticator Authenticator, email string) error {
+	user, err := authenticator.GetUserByEmail(ctx, email)
 	if err != nil {
-		return err
-	}
-	if user == nil {
-		return fmt.Errorf("RemoveAdmin Error: User with email %s not found", email)
+		return fmt.Errorf("RemoveAdmin Error: User with email %s not found: %w", email, err)
 	}
 
 	// Set custom claims for non-admin role
-	currentCustomClaims := user.CustomClaims
-	if currentCustomClaims == nil {
-		currentCustomClaims = map[string]interface{}{}
-	}
-	currentCustomClaims["role"] = "user"
-
-	delete(currentCustomClaims, "admin")
-
-	if err := client.SetCustomUserClaims(ctx, user.UID, currentCustomClaims); err != nil {
+	if err := authenticator.SetRole(ctx, user.UID, RoleUser); err != nil {
 		return fmt.Errorf("RemoveAdmin Error: Error setting custom claims %w", err)
 	}
 
 	return nil
 }
 
-func MakeUser(ctx context.Context, client *auth.Client, userID string) error {
-	user, err := client.GetUser(ctx, userID)
-	if err != nil {
-		return err
-	}
-	if user == nil {
-		return fmt.Errorf("MakeUser Error: User with ID %s not found", userID)
-	}
-
-	currentCustomClaims := user.CustomClaims
-	if currentCustomClaims == nil {
-		currentCustomClaims = map[string]interface{}{}
-	}
-	currentCustomClaims["role"] = "user" // Set the user role as user
-
-	if err := client.SetCustomUserClaims(ctx, user.UID, currentCustomClaims); err != nil {
+func MakeUser(ctx context.Context, authenticator Authenticator, userID string) error {
+	if err := authenticator.SetRole(ctx, userID, RoleUser); err != nil {
 		return fmt.Errorf("MakeUser Error: Error setting custom claims: %w", err)
 	}
 
@@ -19,11 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ProfileService_CreateUpdateProfile_FullMethodName = "/profilepb.ProfileService/CreateUpdateProfile"
-	ProfileService_GetProfile_FullMethodName          = "/profilepb.ProfileService/GetProfile"
-	ProfileService_GetAllProfiles_FullMethodName      = "/profilepb.ProfileService/GetAllProfiles"
-	ProfileService_DeleteProfile_FullMethodName       = "/profilepb.ProfileService/DeleteProfile"
-	ProfileService_UpdateScore_FullMethodName         = "/profilepb.ProfileService/UpdateScore"
+	ProfileService_CreateUpdateProfile_FullMethodName    = "/profilepb.ProfileService/CreateUpdateProfile"
+	ProfileService_GetProfile_FullMethodName             = "/profilepb.ProfileService/GetProfile"
+	ProfileService_GetAllProfiles_FullMethodName         = "/profilepb.ProfileService/GetAllProfiles"
+	ProfileService_DeleteProfile_FullMethodName          = "/profilepb.ProfileService/DeleteProfile"
+	ProfileService_UpdateScore_FullMethodName            = "/profilepb.ProfileService/UpdateScore"
+	ProfileService_AddScore_FullMethodName               = "/profilepb.ProfileService/AddScore"
+	ProfileService_CheckUsernameAvailable_FullMethodName = "/profilepb.ProfileService/CheckUsernameAvailable"
+	ProfileService_ExportMyData_FullMethodName           = "/profilepb.ProfileService/ExportMyData"
 )
 
 // ProfileServiceClient is the client API for ProfileService service.
@@ -32,9 +35,12 @@ const (
 type ProfileServiceClient interface {
 	CreateUpdateProfile(ctx context.Context, in *CreateUpdateProfileRequest, opts ...grpc.CallOption) (*Profile, error)
 	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*Profile, error)
-	GetAllProfiles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (ProfileService_GetAllProfilesClient, error)
+	GetAllProfiles(ctx context.Context, in *GetAllProfilesRequest, opts ...grpc.CallOption) (*GetAllProfilesResponse, error)
 	DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*Empty, error)
 	UpdateScore(ctx context.Context, in *UpdateScoreRequest, opts ...grpc.CallOption) (*Empty, error)
+	AddScore(ctx context.Context, in *AddScoreRequest, opts ...grpc.CallOption) (*AddScoreResponse, error)
+	CheckUsernameAvailable(ctx context.Context, in *CheckUsernameAvailableRequest, opts ...grpc.CallOption) (*CheckUsernameAvailableResponse, error)
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*ProfileExport, error)
 }
 
 type profileServiceClient struct {
@@ -63,50 +69,54 @@ func (c *profileServiceClient) GetProfile(ctx context.Context, in *GetProfileReq
 	return out, nil
 }
 
-func (c *profileServiceClient) GetAllProfiles(ctx context.Context, in *Empty, opts ...grpc.CallOption) (ProfileService_GetAllProfilesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &ProfileService_ServiceDesc.Streams[0], ProfileService_GetAllProfiles_FullMethodName, opts...)
+func (c *profileServiceClient) GetAllProfiles(ctx context.Context, in *GetAllProfilesRequest, opts ...grpc.CallOption) (*GetAllProfilesResponse, error) {
+	out := new(GetAllProfilesResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetAllProfiles_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &profileServiceGetAllProfilesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+	return out, nil
 }
 
-type ProfileService_GetAllProfilesClient interface {
-	Recv() (*Profile, error)
-	grpc.ClientStream
+func (c *profileServiceClient) DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ProfileService_DeleteProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-type profileServiceGetAllProfilesClient struct {
-	grpc.ClientStream
+func (c *profileServiceClient) UpdateScore(ctx context.Context, in *UpdateScoreRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ProfileService_UpdateScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (x *profileServiceGetAllProfilesClient) Recv() (*Profile, error) {
-	m := new(Profile)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (c *profileServiceClient) AddScore(ctx context.Context, in *AddScoreRequest, opts ...grpc.CallOption) (*AddScoreResponse, error) {
+	out := new(AddScoreResponse)
+	err := c.cc.Invoke(ctx, ProfileService_AddScore_FullMethodName, in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	return m, nil
+	return out, nil
 }
 
-func (c *profileServiceClient) DeleteProfile(ctx context.Context, in *DeleteProfileRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, ProfileService_DeleteProfile_FullMethodName, in, out, opts...)
+func (c *profileServiceClient) CheckUsernameAvailable(ctx context.Context, in *CheckUsernameAvailableRequest, opts ...grpc.CallOption) (*CheckUsernameAvailableResponse, error) {
+	out := new(CheckUsernameAvailableResponse)
+	err := c.cc.Invoke(ctx, ProfileService_CheckUsernameAvailable_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *profileServiceClient) UpdateScore(ctx context.Context, in *UpdateScoreRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, ProfileService_UpdateScore_FullMethodName, in, out, opts...)
+func (c *profileServiceClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*ProfileExport, error) {
+	out := new(ProfileExport)
+	err := c.cc.Invoke(ctx, ProfileService_ExportMyData_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -119,9 +129,12 @@ func (c *profileServiceClient) UpdateScore(ctx context.Context, in *UpdateScoreR
 type ProfileServiceServer interface {
 	CreateUpdateProfile(context.Context, *CreateUpdateProfileRequest) (*Profile, error)
 	GetProfile(context.Context, *GetProfileRequest) (*Profile, error)
-	GetAllProfiles(*Empty, ProfileService_GetAllProfilesServer) error
+	GetAllProfiles(context.Context, *GetAllProfilesRequest) (*GetAllProfilesResponse, error)
 	DeleteProfile(context.Context, *DeleteProfileRequest) (*Empty, error)
 	UpdateScore(context.Context, *UpdateScoreRequest) (*Empty, error)
+	AddScore(context.Context, *AddScoreRequest) (*AddScoreResponse, error)
+	CheckUsernameAvailable(context.Context, *CheckUsernameAvailableRequest) (*CheckUsernameAvailableResponse, error)
+	ExportMyData(context.Context, *ExportMyDataRequest) (*ProfileExport, error)
 	mustEmbedUnimplementedProfileServiceServer()
 }
 
@@ -135,8 +148,8 @@ func (UnimplementedProfileServiceServer) CreateUpdateProfile(context.Context, *C
 func (UnimplementedProfileServiceServer) GetProfile(context.Context, *GetProfileRequest) (*Profile, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProfile not implemented")
 }
-func (UnimplementedProfileServiceServer) GetAllProfiles(*Empty, ProfileService_GetAllProfilesServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetAllProfiles not implemented")
+func (UnimplementedProfileServiceServer) GetAllProfiles(context.Context, *GetAllProfilesRequest) (*GetAllProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllProfiles not implemented")
 }
 func (UnimplementedProfileServiceServer) DeleteProfile(context.Context, *DeleteProfileRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteProfile not implemented")
@@ -144,6 +157,15 @@ func (UnimplementedProfileServiceServer) DeleteProfile(context.Context, *DeleteP
 func (UnimplementedProfileServiceServer) UpdateScore(context.Context, *UpdateScoreRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateScore not implemented")
 }
+func (UnimplementedProfileServiceServer) AddScore(context.Context, *AddScoreRequest) (*AddScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddScore not implemented")
+}
+func (UnimplementedProfileServiceServer) CheckUsernameAvailable(context.Context, *CheckUsernameAvailableRequest) (*CheckUsernameAvailableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckUsernameAvailable not implemented")
+}
+func (UnimplementedProfileServiceServer) ExportMyData(context.Context, *ExportMyDataRequest) (*ProfileExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
 func (UnimplementedProfileServiceServer) mustEmbedUnimplementedProfileServiceServer() {}
 
 // UnsafeProfileServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -193,25 +215,22 @@ func _ProfileService_GetProfile_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ProfileService_GetAllProfiles_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(Empty)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _ProfileService_GetAllProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(ProfileServiceServer).GetAllProfiles(m, &profileServiceGetAllProfilesServer{stream})
-}
-
-type ProfileService_GetAllProfilesServer interface {
-	Send(*Profile) error
-	grpc.ServerStream
-}
-
-type profileServiceGetAllProfilesServer struct {
-	grpc.ServerStream
-}
-
-func (x *profileServiceGetAllProfilesServer) Send(m *Profile) error {
-	return x.ServerStream.SendMsg(m)
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetAllProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetAllProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetAllProfiles(ctx, req.(*GetAllProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 func _ProfileService_DeleteProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -250,6 +269,60 @@ func _ProfileService_UpdateScore_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_AddScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).AddScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_AddScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).AddScore(ctx, req.(*AddScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_CheckUsernameAvailable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckUsernameAvailableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).CheckUsernameAvailable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_CheckUsernameAvailable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).CheckUsernameAvailable(ctx, req.(*CheckUsernameAvailableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileService_ServiceDesc is the grpc.ServiceDesc for ProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +338,10 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProfile",
 			Handler:    _ProfileService_GetProfile_Handler,
 		},
+		{
+			MethodName: "GetAllProfiles",
+			Handler:    _ProfileService_GetAllProfiles_Handler,
+		},
 		{
 			MethodName: "DeleteProfile",
 			Handler:    _ProfileService_DeleteProfile_Handler,
@@ -273,13 +350,19 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateScore",
 			Handler:    _ProfileService_UpdateScore_Handler,
 		},
-	},
-	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "GetAllProfiles",
-			Handler:       _ProfileService_GetAllProfiles_Handler,
-			ServerStreams: true,
+			MethodName: "AddScore",
+			Handler:    _ProfileService_AddScore_Handler,
+		},
+		{
+			MethodName: "CheckUsernameAvailable",
+			Handler:    _ProfileService_CheckUsernameAvailable_Handler,
+		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _ProfileService_ExportMyData_Handler,
 		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "profile.proto",
 }
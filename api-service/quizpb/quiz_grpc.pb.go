@@ -0,0 +1,1077 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.2
+// source: quiz.proto
+
+package quiz
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	QuizService_GetQuiz_FullMethodName          = "/quizpb.QuizService/GetQuiz"
+	QuizService_GetLeaderBoard_FullMethodName   = "/quizpb.QuizService/GetLeaderBoard"
+	QuizService_GetScore_FullMethodName         = "/quizpb.QuizService/GetScore"
+	QuizService_GetResult_FullMethodName        = "/quizpb.QuizService/GetResult"
+	QuizService_CreateUpdateQuiz_FullMethodName = "/quizpb.QuizService/CreateUpdateQuiz"
+	QuizService_DeleteQuiz_FullMethodName       = "/quizpb.QuizService/DeleteQuiz"
+	QuizService_ListTrash_FullMethodName        = "/quizpb.QuizService/ListTrash"
+	QuizService_RestoreQuiz_FullMethodName      = "/quizpb.QuizService/RestoreQuiz"
+	QuizService_PurgeTrash_FullMethodName       = "/quizpb.QuizService/PurgeTrash"
+	QuizService_GetQuizHistory_FullMethodName   = "/quizpb.QuizService/GetQuizHistory"
+	QuizService_RevertQuiz_FullMethodName       = "/quizpb.QuizService/RevertQuiz"
+	QuizService_GetAllQuizzes_FullMethodName    = "/quizpb.QuizService/GetAllQuizzes"
+	QuizService_CreateUpdateDeck_FullMethodName = "/quizpb.QuizService/CreateUpdateDeck"
+	QuizService_DeleteDeck_FullMethodName       = "/quizpb.QuizService/DeleteDeck"
+	QuizService_ListDecks_FullMethodName        = "/quizpb.QuizService/ListDecks"
+	QuizService_AssignDeck_FullMethodName       = "/quizpb.QuizService/AssignDeck"
+	QuizService_ListTags_FullMethodName         = "/quizpb.QuizService/ListTags"
+	QuizService_AssignTags_FullMethodName       = "/quizpb.QuizService/AssignTags"
+	QuizService_ImportQuizzes_FullMethodName    = "/quizpb.QuizService/ImportQuizzes"
+	QuizService_ExportQuizzes_FullMethodName    = "/quizpb.QuizService/ExportQuizzes"
+	QuizService_ExportMyData_FullMethodName     = "/quizpb.QuizService/ExportMyData"
+)
+
+// QuizServiceClient is the client API for QuizService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type QuizServiceClient interface {
+	GetQuiz(ctx context.Context, in *GetQuizRequest, opts ...grpc.CallOption) (*GetQuizResponse, error)
+	GetLeaderBoard(ctx context.Context, in *GetLeaderBoardRequest, opts ...grpc.CallOption) (QuizService_GetLeaderBoardClient, error)
+	GetScore(ctx context.Context, in *GetScoreRequest, opts ...grpc.CallOption) (*GetScoreResponse, error)
+	GetResult(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*GetResultResponse, error)
+	CreateUpdateQuiz(ctx context.Context, in *CreateUpdateQuizRequest, opts ...grpc.CallOption) (*Empty, error)
+	// DeleteQuiz moves quizzes to the trash, PurgeTrash deletes the ones
+	// older than the retention period for good
+	DeleteQuiz(ctx context.Context, in *DeleteQuizRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListTrash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (QuizService_ListTrashClient, error)
+	RestoreQuiz(ctx context.Context, in *RestoreQuizRequest, opts ...grpc.CallOption) (*Empty, error)
+	PurgeTrash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PurgeTrashResponse, error)
+	GetQuizHistory(ctx context.Context, in *GetQuizHistoryRequest, opts ...grpc.CallOption) (QuizService_GetQuizHistoryClient, error)
+	RevertQuiz(ctx context.Context, in *RevertQuizRequest, opts ...grpc.CallOption) (*Quiz, error)
+	GetAllQuizzes(ctx context.Context, in *GetAllQuizzesRequest, opts ...grpc.CallOption) (QuizService_GetAllQuizzesClient, error)
+	CreateUpdateDeck(ctx context.Context, in *Deck, opts ...grpc.CallOption) (*Deck, error)
+	DeleteDeck(ctx context.Context, in *DeleteDeckRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListDecks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (QuizService_ListDecksClient, error)
+	AssignDeck(ctx context.Context, in *AssignDeckRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListTags(ctx context.Context, in *Empty, opts ...grpc.CallOption) (QuizService_ListTagsClient, error)
+	AssignTags(ctx context.Context, in *AssignTagsRequest, opts ...grpc.CallOption) (*Empty, error)
+	ImportQuizzes(ctx context.Context, opts ...grpc.CallOption) (QuizService_ImportQuizzesClient, error)
+	ExportQuizzes(ctx context.Context, in *ExportQuizzesRequest, opts ...grpc.CallOption) (QuizService_ExportQuizzesClient, error)
+	ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*QuizExport, error)
+}
+
+type quizServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewQuizServiceClient(cc grpc.ClientConnInterface) QuizServiceClient {
+	return &quizServiceClient{cc}
+}
+
+func (c *quizServiceClient) GetQuiz(ctx context.Context, in *GetQuizRequest, opts ...grpc.CallOption) (*GetQuizResponse, error) {
+	out := new(GetQuizResponse)
+	err := c.cc.Invoke(ctx, QuizService_GetQuiz_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetLeaderBoard(ctx context.Context, in *GetLeaderBoardRequest, opts ...grpc.CallOption) (QuizService_GetLeaderBoardClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[0], QuizService_GetLeaderBoard_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizServiceGetLeaderBoardClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuizService_GetLeaderBoardClient interface {
+	Recv() (*LeaderBoard, error)
+	grpc.ClientStream
+}
+
+type quizServiceGetLeaderBoardClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizServiceGetLeaderBoardClient) Recv() (*LeaderBoard, error) {
+	m := new(LeaderBoard)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizServiceClient) GetScore(ctx context.Context, in *GetScoreRequest, opts ...grpc.CallOption) (*GetScoreResponse, error) {
+	out := new(GetScoreResponse)
+	err := c.cc.Invoke(ctx, QuizService_GetScore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetResult(ctx context.Context, in *GetResultRequest, opts ...grpc.CallOption) (*GetResultResponse, error) {
+	out := new(GetResultResponse)
+	err := c.cc.Invoke(ctx, QuizService_GetResult_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) CreateUpdateQuiz(ctx context.Context, in *CreateUpdateQuizRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, QuizService_CreateUpdateQuiz_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) DeleteQuiz(ctx context.Context, in *DeleteQuizRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, QuizService_DeleteQuiz_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ListTrash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (QuizService_ListTrashClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[1], QuizService_ListTrash_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizServiceListTrashClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuizService_ListTrashClient interface {
+	Recv() (*Quiz, error)
+	grpc.ClientStream
+}
+
+type quizServiceListTrashClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizServiceListTrashClient) Recv() (*Quiz, error) {
+	m := new(Quiz)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizServiceClient) RestoreQuiz(ctx context.Context, in *RestoreQuizRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, QuizService_RestoreQuiz_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) PurgeTrash(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PurgeTrashResponse, error) {
+	out := new(PurgeTrashResponse)
+	err := c.cc.Invoke(ctx, QuizService_PurgeTrash_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetQuizHistory(ctx context.Context, in *GetQuizHistoryRequest, opts ...grpc.CallOption) (QuizService_GetQuizHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[2], QuizService_GetQuizHistory_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizServiceGetQuizHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuizService_GetQuizHistoryClient interface {
+	Recv() (*QuizRevision, error)
+	grpc.ClientStream
+}
+
+type quizServiceGetQuizHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizServiceGetQuizHistoryClient) Recv() (*QuizRevision, error) {
+	m := new(QuizRevision)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizServiceClient) RevertQuiz(ctx context.Context, in *RevertQuizRequest, opts ...grpc.CallOption) (*Quiz, error) {
+	out := new(Quiz)
+	err := c.cc.Invoke(ctx, QuizService_RevertQuiz_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) GetAllQuizzes(ctx context.Context, in *GetAllQuizzesRequest, opts ...grpc.CallOption) (QuizService_GetAllQuizzesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[3], QuizService_GetAllQuizzes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizServiceGetAllQuizzesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuizService_GetAllQuizzesClient interface {
+	Recv() (*Quiz, error)
+	grpc.ClientStream
+}
+
+type quizServiceGetAllQuizzesClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizServiceGetAllQuizzesClient) Recv() (*Quiz, error) {
+	m := new(Quiz)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizServiceClient) CreateUpdateDeck(ctx context.Context, in *Deck, opts ...grpc.CallOption) (*Deck, error) {
+	out := new(Deck)
+	err := c.cc.Invoke(ctx, QuizService_CreateUpdateDeck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) DeleteDeck(ctx context.Context, in *DeleteDeckRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, QuizService_DeleteDeck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ListDecks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (QuizService_ListDecksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[4], QuizService_ListDecks_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizServiceListDecksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuizService_ListDecksClient interface {
+	Recv() (*Deck, error)
+	grpc.ClientStream
+}
+
+type quizServiceListDecksClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizServiceListDecksClient) Recv() (*Deck, error) {
+	m := new(Deck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizServiceClient) AssignDeck(ctx context.Context, in *AssignDeckRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, QuizService_AssignDeck_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ListTags(ctx context.Context, in *Empty, opts ...grpc.CallOption) (QuizService_ListTagsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[5], QuizService_ListTags_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizServiceListTagsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuizService_ListTagsClient interface {
+	Recv() (*Tag, error)
+	grpc.ClientStream
+}
+
+type quizServiceListTagsClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizServiceListTagsClient) Recv() (*Tag, error) {
+	m := new(Tag)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizServiceClient) AssignTags(ctx context.Context, in *AssignTagsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, QuizService_AssignTags_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *quizServiceClient) ImportQuizzes(ctx context.Context, opts ...grpc.CallOption) (QuizService_ImportQuizzesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[6], QuizService_ImportQuizzes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizServiceImportQuizzesClient{stream}
+	return x, nil
+}
+
+type QuizService_ImportQuizzesClient interface {
+	Send(*ImportQuizzesRequest) error
+	CloseAndRecv() (*ImportQuizzesResponse, error)
+	grpc.ClientStream
+}
+
+type quizServiceImportQuizzesClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizServiceImportQuizzesClient) Send(m *ImportQuizzesRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *quizServiceImportQuizzesClient) CloseAndRecv() (*ImportQuizzesResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportQuizzesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizServiceClient) ExportQuizzes(ctx context.Context, in *ExportQuizzesRequest, opts ...grpc.CallOption) (QuizService_ExportQuizzesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &QuizService_ServiceDesc.Streams[7], QuizService_ExportQuizzes_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &quizServiceExportQuizzesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type QuizService_ExportQuizzesClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type quizServiceExportQuizzesClient struct {
+	grpc.ClientStream
+}
+
+func (x *quizServiceExportQuizzesClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *quizServiceClient) ExportMyData(ctx context.Context, in *ExportMyDataRequest, opts ...grpc.CallOption) (*QuizExport, error) {
+	out := new(QuizExport)
+	err := c.cc.Invoke(ctx, QuizService_ExportMyData_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QuizServiceServer is the server API for QuizService service.
+// All implementations must embed UnimplementedQuizServiceServer
+// for forward compatibility
+type QuizServiceServer interface {
+	GetQuiz(context.Context, *GetQuizRequest) (*GetQuizResponse, error)
+	GetLeaderBoard(*GetLeaderBoardRequest, QuizService_GetLeaderBoardServer) error
+	GetScore(context.Context, *GetScoreRequest) (*GetScoreResponse, error)
+	GetResult(context.Context, *GetResultRequest) (*GetResultResponse, error)
+	CreateUpdateQuiz(context.Context, *CreateUpdateQuizRequest) (*Empty, error)
+	// DeleteQuiz moves quizzes to the trash, PurgeTrash deletes the ones
+	// older than the retention period for good
+	DeleteQuiz(context.Context, *DeleteQuizRequest) (*Empty, error)
+	ListTrash(*Empty, QuizService_ListTrashServer) error
+	RestoreQuiz(context.Context, *RestoreQuizRequest) (*Empty, error)
+	PurgeTrash(context.Context, *Empty) (*PurgeTrashResponse, error)
+	GetQuizHistory(*GetQuizHistoryRequest, QuizService_GetQuizHistoryServer) error
+	RevertQuiz(context.Context, *RevertQuizRequest) (*Quiz, error)
+	GetAllQuizzes(*GetAllQuizzesRequest, QuizService_GetAllQuizzesServer) error
+	CreateUpdateDeck(context.Context, *Deck) (*Deck, error)
+	DeleteDeck(context.Context, *DeleteDeckRequest) (*Empty, error)
+	ListDecks(*Empty, QuizService_ListDecksServer) error
+	AssignDeck(context.Context, *AssignDeckRequest) (*Empty, error)
+	ListTags(*Empty, QuizService_ListTagsServer) error
+	AssignTags(context.Context, *AssignTagsRequest) (*Empty, error)
+	ImportQuizzes(QuizService_ImportQuizzesServer) error
+	ExportQuizzes(*ExportQuizzesRequest, QuizService_ExportQuizzesServer) error
+	ExportMyData(context.Context, *ExportMyDataRequest) (*QuizExport, error)
+	mustEmbedUnimplementedQuizServiceServer()
+}
+
+// UnimplementedQuizServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedQuizServiceServer struct {
+}
+
+func (UnimplementedQuizServiceServer) GetQuiz(context.Context, *GetQuizRequest) (*GetQuizResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuiz not implemented")
+}
+func (UnimplementedQuizServiceServer) GetLeaderBoard(*GetLeaderBoardRequest, QuizService_GetLeaderBoardServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetLeaderBoard not implemented")
+}
+func (UnimplementedQuizServiceServer) GetScore(context.Context, *GetScoreRequest) (*GetScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScore not implemented")
+}
+func (UnimplementedQuizServiceServer) GetResult(context.Context, *GetResultRequest) (*GetResultResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResult not implemented")
+}
+func (UnimplementedQuizServiceServer) CreateUpdateQuiz(context.Context, *CreateUpdateQuizRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUpdateQuiz not implemented")
+}
+func (UnimplementedQuizServiceServer) DeleteQuiz(context.Context, *DeleteQuizRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteQuiz not implemented")
+}
+func (UnimplementedQuizServiceServer) ListTrash(*Empty, QuizService_ListTrashServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedQuizServiceServer) RestoreQuiz(context.Context, *RestoreQuizRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreQuiz not implemented")
+}
+func (UnimplementedQuizServiceServer) PurgeTrash(context.Context, *Empty) (*PurgeTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeTrash not implemented")
+}
+func (UnimplementedQuizServiceServer) GetQuizHistory(*GetQuizHistoryRequest, QuizService_GetQuizHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetQuizHistory not implemented")
+}
+func (UnimplementedQuizServiceServer) RevertQuiz(context.Context, *RevertQuizRequest) (*Quiz, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertQuiz not implemented")
+}
+func (UnimplementedQuizServiceServer) GetAllQuizzes(*GetAllQuizzesRequest, QuizService_GetAllQuizzesServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetAllQuizzes not implemented")
+}
+func (UnimplementedQuizServiceServer) CreateUpdateDeck(context.Context, *Deck) (*Deck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUpdateDeck not implemented")
+}
+func (UnimplementedQuizServiceServer) DeleteDeck(context.Context, *DeleteDeckRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDeck not implemented")
+}
+func (UnimplementedQuizServiceServer) ListDecks(*Empty, QuizService_ListDecksServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListDecks not implemented")
+}
+func (UnimplementedQuizServiceServer) AssignDeck(context.Context, *AssignDeckRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignDeck not implemented")
+}
+func (UnimplementedQuizServiceServer) ListTags(*Empty, QuizService_ListTagsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedQuizServiceServer) AssignTags(context.Context, *AssignTagsRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignTags not implemented")
+}
+func (UnimplementedQuizServiceServer) ImportQuizzes(QuizService_ImportQuizzesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportQuizzes not implemented")
+}
+func (UnimplementedQuizServiceServer) ExportQuizzes(*ExportQuizzesRequest, QuizService_ExportQuizzesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportQuizzes not implemented")
+}
+func (UnimplementedQuizServiceServer) ExportMyData(context.Context, *ExportMyDataRequest) (*QuizExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedQuizServiceServer) mustEmbedUnimplementedQuizServiceServer() {}
+
+// UnsafeQuizServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to QuizServiceServer will
+// result in compilation errors.
+type UnsafeQuizServiceServer interface {
+	mustEmbedUnimplementedQuizServiceServer()
+}
+
+func RegisterQuizServiceServer(s grpc.ServiceRegistrar, srv QuizServiceServer) {
+	s.RegisterService(&QuizService_ServiceDesc, srv)
+}
+
+func _QuizService_GetQuiz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuizRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetQuiz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetQuiz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetQuiz(ctx, req.(*GetQuizRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetLeaderBoard_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetLeaderBoardRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServiceServer).GetLeaderBoard(m, &quizServiceGetLeaderBoardServer{stream})
+}
+
+type QuizService_GetLeaderBoardServer interface {
+	Send(*LeaderBoard) error
+	grpc.ServerStream
+}
+
+type quizServiceGetLeaderBoardServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizServiceGetLeaderBoardServer) Send(m *LeaderBoard) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QuizService_GetScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetScore(ctx, req.(*GetScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).GetResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_GetResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).GetResult(ctx, req.(*GetResultRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_CreateUpdateQuiz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUpdateQuizRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).CreateUpdateQuiz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_CreateUpdateQuiz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).CreateUpdateQuiz(ctx, req.(*CreateUpdateQuizRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_DeleteQuiz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteQuizRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).DeleteQuiz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_DeleteQuiz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).DeleteQuiz(ctx, req.(*DeleteQuizRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ListTrash_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServiceServer).ListTrash(m, &quizServiceListTrashServer{stream})
+}
+
+type QuizService_ListTrashServer interface {
+	Send(*Quiz) error
+	grpc.ServerStream
+}
+
+type quizServiceListTrashServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizServiceListTrashServer) Send(m *Quiz) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QuizService_RestoreQuiz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreQuizRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).RestoreQuiz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_RestoreQuiz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).RestoreQuiz(ctx, req.(*RestoreQuizRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_PurgeTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).PurgeTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_PurgeTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).PurgeTrash(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetQuizHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetQuizHistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServiceServer).GetQuizHistory(m, &quizServiceGetQuizHistoryServer{stream})
+}
+
+type QuizService_GetQuizHistoryServer interface {
+	Send(*QuizRevision) error
+	grpc.ServerStream
+}
+
+type quizServiceGetQuizHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizServiceGetQuizHistoryServer) Send(m *QuizRevision) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QuizService_RevertQuiz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertQuizRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).RevertQuiz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_RevertQuiz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).RevertQuiz(ctx, req.(*RevertQuizRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_GetAllQuizzes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAllQuizzesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServiceServer).GetAllQuizzes(m, &quizServiceGetAllQuizzesServer{stream})
+}
+
+type QuizService_GetAllQuizzesServer interface {
+	Send(*Quiz) error
+	grpc.ServerStream
+}
+
+type quizServiceGetAllQuizzesServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizServiceGetAllQuizzesServer) Send(m *Quiz) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QuizService_CreateUpdateDeck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Deck)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).CreateUpdateDeck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_CreateUpdateDeck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).CreateUpdateDeck(ctx, req.(*Deck))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_DeleteDeck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDeckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).DeleteDeck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_DeleteDeck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).DeleteDeck(ctx, req.(*DeleteDeckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ListDecks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServiceServer).ListDecks(m, &quizServiceListDecksServer{stream})
+}
+
+type QuizService_ListDecksServer interface {
+	Send(*Deck) error
+	grpc.ServerStream
+}
+
+type quizServiceListDecksServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizServiceListDecksServer) Send(m *Deck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QuizService_AssignDeck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignDeckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).AssignDeck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_AssignDeck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).AssignDeck(ctx, req.(*AssignDeckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ListTags_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServiceServer).ListTags(m, &quizServiceListTagsServer{stream})
+}
+
+type QuizService_ListTagsServer interface {
+	Send(*Tag) error
+	grpc.ServerStream
+}
+
+type quizServiceListTagsServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizServiceListTagsServer) Send(m *Tag) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QuizService_AssignTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).AssignTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_AssignTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).AssignTags(ctx, req.(*AssignTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuizService_ImportQuizzes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(QuizServiceServer).ImportQuizzes(&quizServiceImportQuizzesServer{stream})
+}
+
+type QuizService_ImportQuizzesServer interface {
+	SendAndClose(*ImportQuizzesResponse) error
+	Recv() (*ImportQuizzesRequest, error)
+	grpc.ServerStream
+}
+
+type quizServiceImportQuizzesServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizServiceImportQuizzesServer) SendAndClose(m *ImportQuizzesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *quizServiceImportQuizzesServer) Recv() (*ImportQuizzesRequest, error) {
+	m := new(ImportQuizzesRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _QuizService_ExportQuizzes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportQuizzesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QuizServiceServer).ExportQuizzes(m, &quizServiceExportQuizzesServer{stream})
+}
+
+type QuizService_ExportQuizzesServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
+}
+
+type quizServiceExportQuizzesServer struct {
+	grpc.ServerStream
+}
+
+func (x *quizServiceExportQuizzesServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _QuizService_ExportMyData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportMyDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuizServiceServer).ExportMyData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuizService_ExportMyData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuizServiceServer).ExportMyData(ctx, req.(*ExportMyDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// QuizService_ServiceDesc is the grpc.ServiceDesc for QuizService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var QuizService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "quizpb.QuizService",
+	HandlerType: (*QuizServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetQuiz",
+			Handler:    _QuizService_GetQuiz_Handler,
+		},
+		{
+			MethodName: "GetScore",
+			Handler:    _QuizService_GetScore_Handler,
+		},
+		{
+			MethodName: "GetResult",
+			Handler:    _QuizService_GetResult_Handler,
+		},
+		{
+			MethodName: "CreateUpdateQuiz",
+			Handler:    _QuizService_CreateUpdateQuiz_Handler,
+		},
+		{
+			MethodName: "DeleteQuiz",
+			Handler:    _QuizService_DeleteQuiz_Handler,
+		},
+		{
+			MethodName: "RestoreQuiz",
+			Handler:    _QuizService_RestoreQuiz_Handler,
+		},
+		{
+			MethodName: "PurgeTrash",
+			Handler:    _QuizService_PurgeTrash_Handler,
+		},
+		{
+			MethodName: "RevertQuiz",
+			Handler:    _QuizService_RevertQuiz_Handler,
+		},
+		{
+			MethodName: "CreateUpdateDeck",
+			Handler:    _QuizService_CreateUpdateDeck_Handler,
+		},
+		{
+			MethodName: "DeleteDeck",
+			Handler:    _QuizService_DeleteDeck_Handler,
+		},
+		{
+			MethodName: "AssignDeck",
+			Handler:    _QuizService_AssignDeck_Handler,
+		},
+		{
+			MethodName: "AssignTags",
+			Handler:    _QuizService_AssignTags_Handler,
+		},
+		{
+			MethodName: "ExportMyData",
+			Handler:    _QuizService_ExportMyData_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetLeaderBoard",
+			Handler:       _QuizService_GetLeaderBoard_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTrash",
+			Handler:       _QuizService_ListTrash_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetQuizHistory",
+			Handler:       _QuizService_GetQuizHistory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetAllQuizzes",
+			Handler:       _QuizService_GetAllQuizzes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListDecks",
+			Handler:       _QuizService_ListDecks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListTags",
+			Handler:       _QuizService_ListTags_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportQuizzes",
+			Handler:       _QuizService_ImportQuizzes_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportQuizzes",
+			Handler:       _QuizService_ExportQuizzes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "quiz.proto",
+}
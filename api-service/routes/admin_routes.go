@@ -1,36 +1,65 @@
 package routes
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"regexp"
 
-	"firebase.google.com/go/v4/auth"
 	"github.com/Cprime50/api-service/middleware"
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterAdminRoutes(r *gin.Engine, client *auth.Client) {
+func RegisterAdminRoutes(r *gin.Engine, authenticator middleware.Authenticator, h *Handler) {
 
 	adminRoutes := r.Group("/admin")
-	adminRoutes.Use(middleware.Auth(client), middleware.RoleAuth("admin"))
+	adminRoutes.Use(middleware.Auth(authenticator))
 	{
-		adminRoutes.POST("/make", func(ctx *gin.Context) {
-			makeAdmin(ctx, client)
+		grant := middleware.RequirePermission(middleware.PermAdminGrant)
+		adminRoutes.POST("/make", grant, func(ctx *gin.Context) {
+			makeAdmin(ctx, authenticator)
 		})
-		adminRoutes.DELETE("/remove", func(ctx *gin.Context) {
-			removeAdmin(ctx, client)
+		adminRoutes.DELETE("/remove", grant, func(ctx *gin.Context) {
+			removeAdmin(ctx, authenticator)
 		})
+		adminRoutes.GET("/roles", grant, getRoles)
+		adminRoutes.PUT("/role", grant, func(ctx *gin.Context) {
+			assignRole(ctx, authenticator)
+		})
+
+		review := middleware.RequirePermission(middleware.PermQuizReview)
+		write := middleware.RequirePermission(middleware.PermQuizWrite)
+		adminRoutes.GET("/quiz", review, h.GetAllQuizzes)
+		adminRoutes.POST("/quiz", write, h.CreateQuizzes)
+		adminRoutes.PUT("/quiz", write, h.UpdateQuizzes)
+		adminRoutes.DELETE("/quiz", write, h.DeleteQuizzes)
+		adminRoutes.GET("/quiz/trash", review, h.ListTrash)
+		adminRoutes.POST("/quiz/restore", write, h.RestoreQuizzes)
+		adminRoutes.DELETE("/quiz/trash", write, h.PurgeTrash)
+		adminRoutes.GET("/quiz/:id/history", review, h.GetQuizHistory)
+		adminRoutes.POST("/quiz/revert", write, h.RevertQuiz)
+		adminRoutes.POST("/quiz/import", write, h.ImportQuizzes)
+		adminRoutes.GET("/quiz/export", review, h.ExportQuizzes)
+		adminRoutes.POST("/decks", write, h.CreateDeck)
+		adminRoutes.PUT("/decks/:id", write, h.UpdateDeck)
+		adminRoutes.DELETE("/decks/:id", write, h.DeleteDeck)
+		adminRoutes.PUT("/decks/:id/quizzes", write, h.AssignDeck)
+		adminRoutes.PUT("/tags", write, h.AssignTags)
 	}
 
-} 
+}
 
 type EmailInput struct {
 	Email string `json:"email"`
 }
 
-func makeAdmin(ctx *gin.Context, client *auth.Client) {
+type RoleInput struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+func makeAdmin(ctx *gin.Context, authenticator middleware.Authenticator) {
 	var input EmailInput
 
 	if err := ctx.BindJSON(&input); err != nil {
@@ -46,7 +75,7 @@ func makeAdmin(ctx *gin.Context, client *auth.Client) {
 		return
 	}
 
-	err := middleware.MakeAdmin(ctx.Request.Context(), client, input.Email)
+	err := middleware.MakeAdmin(ctx.Request.Context(), authenticator, input.Email)
 	if err != nil {
 		log.Print("error making admin:", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -55,7 +84,7 @@ func makeAdmin(ctx *gin.Context, client *auth.Client) {
 	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("User %s is now an admin", input.Email)})
 }
 
-func removeAdmin(ctx *gin.Context, client *auth.Client) {
+func removeAdmin(ctx *gin.Context, authenticator middleware.Authenticator) {
 	var input EmailInput
 
 	if err := ctx.BindJSON(&input); err != nil {
@@ -71,7 +100,7 @@ func removeAdmin(ctx *gin.Context, client *auth.Client) {
 		return
 	}
 
-	err := middleware.RemoveAdmin(ctx.Request.Context(), client, input.Email)
+	err := middleware.RemoveAdmin(ctx.Request.Context(), authenticator, input.Email)
 	if err != nil {
 		log.Print("error removing admin:", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -80,6 +109,44 @@ func removeAdmin(ctx *gin.Context, client *auth.Client) {
 	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("User %s admin rights have been revoked", input.Email)})
 }
 
+func getRoles(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, middleware.Roles())
+}
+
+func assignRole(ctx *gin.Context, authenticator middleware.Authenticator) {
+	var input RoleInput
+
+	if err := ctx.BindJSON(&input); err != nil {
+		log.Print("error assigning role: invalid json format", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON format"})
+		return
+	}
+
+	emailOk := ValidateEmailInput(input.Email)
+	if !emailOk {
+		log.Print("error assigning role: Invalid email format")
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Email format"})
+		return
+	}
+	if !middleware.IsRole(input.Role) {
+		log.Printf("error assigning role: unknown role %q", input.Role)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Unknown role"})
+		return
+	}
+
+	err := middleware.AssignRole(ctx.Request.Context(), authenticator, input.Email, input.Role)
+	if err != nil {
+		log.Print("error assigning role:", err)
+		if errors.Is(err, middleware.ErrUserNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("User %s now has the %s role", input.Email, input.Role)})
+}
+
 // Regex for email validation
 func ValidateEmailInput(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
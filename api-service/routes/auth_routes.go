@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/Cprime50/api-service/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterAuthRoutes serves POST /auth/token when tokens are issued locally,
+// so a development or test client can sign in with just an email. Nothing is
+// registered for other providers.
+func RegisterAuthRoutes(r *gin.Engine, authenticator middleware.Authenticator) {
+	local, ok := authenticator.(*middleware.LocalAuth)
+	if !ok {
+		return
+	}
+
+	r.POST("/auth/token", func(ctx *gin.Context) {
+		issueToken(ctx, local)
+	})
+}
+
+func issueToken(ctx *gin.Context, local *middleware.LocalAuth) {
+	var input EmailInput
+
+	if err := ctx.BindJSON(&input); err != nil {
+		log.Print("error issuing token: invalid json format", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON format"})
+		return
+	}
+
+	emailOk := ValidateEmailInput(input.Email)
+	if !emailOk {
+		log.Print("error issuing token: Invalid email format")
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Email format"})
+		return
+	}
+
+	// ADMIN_EMAIL is made admin on first sign in, it has to come from a real
+	// identity provider. AUTH_LOCAL_ADMIN_EMAIL is the local admin
+	if adminEmail := os.Getenv("ADMIN_EMAIL"); adminEmail != "" && middleware.SameEmail(input.Email, adminEmail) {
+		log.Print("error issuing token: refused for the admin email")
+		ctx.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Tokens cannot be issued for this email"})
+		return
+	}
+
+	token, err := local.IssueToken(input.Email)
+	if err != nil {
+		log.Print("error issuing token:", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"token": token})
+}
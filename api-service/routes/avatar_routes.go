@@ -0,0 +1,143 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Cprime50/api-service/middleware"
+	profilepb "github.com/Cprime50/api-service/pb"
+	"github.com/Cprime50/api-service/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxAvatarSize is the largest avatar upload, multipart headers
+	// included.
+	maxAvatarSize = 5 << 20
+	// maxAvatarDimension bounds the width and height of an avatar, so a
+	// small file can't decode to a huge image.
+	maxAvatarDimension = 4096
+)
+
+// avatarSizes are the sides of the square thumbnails made of an avatar, the
+// profile links the largest.
+var avatarSizes = []int{64, 128, 256}
+
+var avatarTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+// UploadAvatar stores thumbnails of the image uploaded as the avatar form
+// file and sets the profile avatar to the largest one.
+func (h *Handler) UploadAvatar(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, transferTimeout)
+	defer cancel()
+
+	userValue, exists := c.Get("user")
+	user, ok := userValue.(*middleware.User)
+	if !exists || !ok || user == nil {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAvatarSize)
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		log.Print("error reading upload for uploadAvatar:", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing or too large file"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		log.Print("error opening upload for uploadAvatar:", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Print("error reading upload for uploadAvatar:", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		return
+	}
+
+	if contentType := http.DetectContentType(data); !avatarTypes[contentType] {
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{"error": "Avatar must be a PNG, JPEG or GIF image"})
+		return
+	}
+	thumbnails, err := avatarThumbnails(data)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// The thumbnails are named after the upload, so a new avatar gets new
+	// URLs and caches of the old one don't linger
+	sum := sha256.Sum256(data)
+	prefix := "avatars/" + hex.EncodeToString(sum[:16])
+	urls := make(map[string]string, len(thumbnails))
+	for i, thumbnail := range thumbnails {
+		size := strconv.Itoa(avatarSizes[i])
+		url, err := h.blobs.Put(ctx, prefix+"/"+size+".png", "image/png", bytes.NewReader(thumbnail))
+		if err != nil {
+			log.Println("Error storing avatar:", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store avatar"})
+			return
+		}
+		urls[size] = url
+	}
+
+	largest := strconv.Itoa(avatarSizes[len(avatarSizes)-1])
+	changes := &profilepb.Profile{Avatar: urls[largest]}
+	profile, err := h.clients.Profile.UpdateProfile(ctx, user, changes, []string{"avatar"}, 0)
+	if err != nil {
+		log.Println("Error updating profile avatar:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.Header("ETag", profileETag(profile))
+	c.JSON(http.StatusOK, gin.H{"profile": profile, "thumbnails": urls})
+}
+
+// avatarThumbnails decodes an avatar image, crops it to a square and returns
+// it as a PNG of each of avatarSizes.
+func avatarThumbnails(data []byte) ([][]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid image")
+	}
+	if config.Width > maxAvatarDimension || config.Height > maxAvatarDimension {
+		return nil, fmt.Errorf("Avatar must be at most %dx%d pixels", maxAvatarDimension, maxAvatarDimension)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid image")
+	}
+	if img.Bounds().Empty() {
+		return nil, fmt.Errorf("Invalid image")
+	}
+
+	square := utils.CropSquare(img)
+	thumbnails := make([][]byte, len(avatarSizes))
+	for i, size := range avatarSizes {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, utils.Thumbnail(square, size)); err != nil {
+			return nil, fmt.Errorf("png.Encode: %w", err)
+		}
+		thumbnails[i] = buf.Bytes()
+	}
+	return thumbnails, nil
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodeImage(t *testing.T, encode func(*bytes.Buffer, image.Image) error, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestAvatarThumbnails(t *testing.T) {
+	pngEncode := func(b *bytes.Buffer, img image.Image) error { return png.Encode(b, img) }
+	jpegEncode := func(b *bytes.Buffer, img image.Image) error { return jpeg.Encode(b, img, nil) }
+	gifEncode := func(b *bytes.Buffer, img image.Image) error { return gif.Encode(b, img, nil) }
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"square png", encodeImage(t, pngEncode, 32, 32), false},
+		{"wide png", encodeImage(t, pngEncode, 300, 40), false},
+		{"tall jpeg", encodeImage(t, jpegEncode, 20, 90), false},
+		{"gif", encodeImage(t, gifEncode, 16, 8), false},
+		{"at the dimension cap", encodeImage(t, pngEncode, maxAvatarDimension, 1), false},
+		{"too wide", encodeImage(t, pngEncode, maxAvatarDimension+1, 1), true},
+		{"too tall", encodeImage(t, pngEncode, 1, maxAvatarDimension+1), true},
+		{"not an image", []byte("hello"), true},
+		{"truncated png", encodeImage(t, pngEncode, 32, 32)[:40], true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnails, err := avatarThumbnails(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("avatarThumbnails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(thumbnails) != len(avatarSizes) {
+				t.Fatalf("avatarThumbnails() returned %d thumbnails, want %d", len(thumbnails), len(avatarSizes))
+			}
+			for i, thumbnail := range thumbnails {
+				config, err := png.DecodeConfig(bytes.NewReader(thumbnail))
+				if err != nil {
+					t.Fatalf("thumbnail %d is not a PNG: %v", i, err)
+				}
+				if config.Width != avatarSizes[i] || config.Height != avatarSizes[i] {
+					t.Errorf("thumbnail %d is %dx%d, want %dx%[4]d", i, config.Width, config.Height, avatarSizes[i])
+				}
+			}
+		})
+	}
+}
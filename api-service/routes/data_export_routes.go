@@ -0,0 +1,383 @@
+package routes
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	profilepb "github.com/Cprime50/api-service/pb"
+	quizpb "github.com/Cprime50/api-service/quizpb"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// exportWait is how long a data export request waits for the export
+	// before answering with where to follow it instead.
+	exportWait = 2 * time.Second
+	// exportTimeout bounds gathering an export from the backends.
+	exportTimeout = time.Minute
+	// exportTTL is how long a finished export can be downloaded.
+	exportTTL = time.Hour
+	// exportSweepInterval is how often expired exports are dropped from
+	// memory.
+	exportSweepInterval = 10 * time.Minute
+)
+
+var exportFormats = map[string]struct {
+	contentType string
+	extension   string
+}{
+	"json": {"application/json", ".json"},
+	"zip":  {"application/zip", ".zip"},
+}
+
+// exportJob is a copy of a user's data being gathered, or gathered, in the
+// background.
+type exportJob struct {
+	id      string
+	userID  string
+	format  string
+	started time.Time
+	// done is closed once data or err is set
+	done chan struct{}
+	data []byte
+	err  error
+}
+
+func (j *exportJob) finished() bool {
+	select {
+	case <-j.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// exportJobs keeps the data exports of the last exportTTL, in memory.
+type exportJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*exportJob
+}
+
+// newExportJobs sweeps expired exports from memory until ctx is done.
+func newExportJobs(ctx context.Context) *exportJobs {
+	e := &exportJobs{jobs: make(map[string]*exportJob)}
+	go func() {
+		ticker := time.NewTicker(exportSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				e.sweep(now)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return e
+}
+
+// sweep drops the exports that finished and expired by now, so their data
+// is not kept until the next one starts.
+func (e *exportJobs) sweep(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for id, job := range e.jobs {
+		if job.finished() && now.Sub(job.started) > exportTTL {
+			delete(e.jobs, id)
+		}
+	}
+}
+
+// start runs build for the user's export in the background and returns the
+// job. The user's export in the same format is returned instead while it is
+// running or can still be downloaded, so a user keeps at most one export per
+// format.
+func (e *exportJobs) start(userID, format string, build func(ctx context.Context) ([]byte, error)) (*exportJob, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	for id, job := range e.jobs {
+		if job.userID != userID || job.format != format {
+			continue
+		}
+		if !job.finished() || (job.err == nil && now.Sub(job.started) <= exportTTL) {
+			return job, nil
+		}
+		// Failed or expired, the new export replaces it
+		delete(e.jobs, id)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("rand.Read: %w", err)
+	}
+	job := &exportJob{
+		id:      hex.EncodeToString(id),
+		userID:  userID,
+		format:  format,
+		started: now,
+		done:    make(chan struct{}),
+	}
+	e.jobs[job.id] = job
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+		job.data, job.err = build(ctx)
+		close(job.done)
+	}()
+	return job, nil
+}
+
+// get returns the user's export with id, users only see their own.
+func (e *exportJobs) get(userID, id string) (*exportJob, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	job, ok := e.jobs[id]
+	if !ok || job.userID != userID || (job.finished() && time.Since(job.started) > exportTTL) {
+		return nil, false
+	}
+	return job, true
+}
+
+// ExportMyData gives users a copy of everything kept about them, as one JSON
+// document or a zip of a JSON file per kind of data. Exports that take
+// longer than exportWait finish in the background, the response then says
+// where to follow them.
+func (h *Handler) ExportMyData(c *gin.Context) {
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if _, ok := exportFormats[format]; !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid format, expected json or zip"})
+		return
+	}
+
+	job, err := h.exports.start(uid, format, func(ctx context.Context) ([]byte, error) {
+		return h.buildDataExport(ctx, uid, format)
+	})
+	if err != nil {
+		log.Println("Error starting data export:", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start export"})
+		return
+	}
+
+	select {
+	case <-job.done:
+		sendDataExport(c, job)
+	case <-time.After(exportWait):
+		c.Header("Location", exportStatusURL(job))
+		c.JSON(http.StatusAccepted, exportStatus(job))
+	case <-c.Request.Context().Done():
+	}
+}
+
+// GetDataExportStatus tells whether a data export is still running, failed
+// or can be downloaded.
+func (h *Handler) GetDataExportStatus(c *gin.Context) {
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	job, ok := h.exports.get(uid, c.Param("id"))
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Export not found"})
+		return
+	}
+	c.JSON(http.StatusOK, exportStatus(job))
+}
+
+// DownloadDataExport sends a finished data export.
+func (h *Handler) DownloadDataExport(c *gin.Context) {
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	job, ok := h.exports.get(uid, c.Param("id"))
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Export not found"})
+		return
+	}
+	if !job.finished() {
+		c.Header("Location", exportStatusURL(job))
+		c.JSON(http.StatusConflict, gin.H{"error": "Export is not ready yet"})
+		return
+	}
+	sendDataExport(c, job)
+}
+
+func exportStatusURL(job *exportJob) string {
+	return "/profile/me/export/" + job.id
+}
+
+func exportStatus(job *exportJob) gin.H {
+	res := gin.H{"id": job.id, "format": job.format, "status": "pending", "started_at": job.started.UTC().Format(time.RFC3339)}
+	if job.finished() {
+		if job.err != nil {
+			res["status"] = "failed"
+			res["error"] = errorMessage(job.err)
+		} else {
+			res["status"] = "ready"
+			res["download_url"] = exportStatusURL(job) + "/download"
+		}
+	}
+	return res
+}
+
+// sendDataExport responds with a finished export as a file download.
+func sendDataExport(c *gin.Context, job *exportJob) {
+	if job.err != nil {
+		log.Println("Error exporting user data:", job.err)
+		c.JSON(httpStatus(job.err), gin.H{"error": errorMessage(job.err)})
+		return
+	}
+	t := exportFormats[job.format]
+	c.Header("Content-Disposition", `attachment; filename="my-data`+t.extension+`"`)
+	c.Data(http.StatusOK, t.contentType, job.data)
+}
+
+// exportSection is one kind of data in an export, a member of the JSON
+// document or a file of the zip.
+type exportSection struct {
+	name string
+	data json.RawMessage
+}
+
+// buildDataExport gathers the user's data from both backends into an
+// export in format. Users without a profile still get their quiz data.
+func (h *Handler) buildDataExport(ctx context.Context, userID, format string) ([]byte, error) {
+	profile, err := h.clients.Profile.ExportMyData(ctx, userID)
+	if status.Code(err) == codes.NotFound {
+		profile, err = &profilepb.ProfileExport{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	quiz, err := h.clients.Quiz.ExportMyData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := exportSections(profile, quiz)
+	if err != nil {
+		return nil, err
+	}
+	if format == "zip" {
+		return exportZip(sections)
+	}
+	return exportJSON(sections)
+}
+
+func exportSections(profile *profilepb.ProfileExport, quiz *quizpb.QuizExport) ([]exportSection, error) {
+	var sections []exportSection
+	add := func(name string, data []byte, err error) error {
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", name, err)
+		}
+		sections = append(sections, exportSection{name, data})
+		return nil
+	}
+
+	// Users without a profile get null rather than a profile of empty fields
+	profileData := json.RawMessage("null")
+	var err error
+	if profile.Profile != nil {
+		profileData, err = exportMarshal.Marshal(profile.Profile)
+	}
+	if err := add("profile", profileData, err); err != nil {
+		return nil, err
+	}
+	events, err := marshalList(profile.ScoreEvents)
+	if err := add("score_history", events, err); err != nil {
+		return nil, err
+	}
+	leaderboard, err := json.Marshal(gin.H{"score": quiz.Score})
+	if err := add("leaderboard", leaderboard, err); err != nil {
+		return nil, err
+	}
+	attempts, err := marshalList(quiz.Attempts)
+	if err := add("quiz_attempts", attempts, err); err != nil {
+		return nil, err
+	}
+	states, err := marshalList(quiz.ReviewStates)
+	if err := add("review_states", states, err); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// exportMarshal writes every field, so the export shows what is kept even
+// when it is empty.
+var exportMarshal = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// marshalList marshals messages as a JSON array.
+func marshalList[M proto.Message](messages []M) ([]byte, error) {
+	list := make([]json.RawMessage, 0, len(messages))
+	for _, m := range messages {
+		data, err := exportMarshal.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, data)
+	}
+	return json.Marshal(list)
+}
+
+// exportJSON writes the sections as members of one JSON document.
+func exportJSON(sections []exportSection) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"exported_at":"` + time.Now().UTC().Format(time.RFC3339) + `"`)
+	for _, s := range sections {
+		buf.WriteString(`,"` + s.name + `":`)
+		buf.Write(s.data)
+	}
+	buf.WriteString("}")
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return nil, fmt.Errorf("json.Indent: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// exportZip writes each section as a JSON file of a zip.
+func exportZip(sections []exportSection) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	now := time.Now()
+	for _, s := range sections {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: s.name + ".json", Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return nil, fmt.Errorf("zip.CreateHeader: %w", err)
+		}
+		var file bytes.Buffer
+		if err := json.Indent(&file, s.data, "", "  "); err != nil {
+			return nil, fmt.Errorf("json.Indent: %w", err)
+		}
+		if _, err := file.WriteTo(w); err != nil {
+			return nil, fmt.Errorf("zip.Write: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("zip.Close: %w", err)
+	}
+	return buf.Bytes(), nil
+}
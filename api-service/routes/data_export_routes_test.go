@@ -0,0 +1,133 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	profilepb "github.com/Cprime50/api-service/pb"
+	quizpb "github.com/Cprime50/api-service/quizpb"
+)
+
+func TestExportSectionsWithoutProfile(t *testing.T) {
+	quiz := &quizpb.QuizExport{Score: 7, Attempts: []*quizpb.QuizAttempt{{SessionId: "s1"}}}
+	sections, err := exportSections(&profilepb.ProfileExport{}, quiz)
+	if err != nil {
+		t.Fatalf("exportSections() error = %v", err)
+	}
+	data, err := exportJSON(sections)
+	if err != nil {
+		t.Fatalf("exportJSON() error = %v", err)
+	}
+
+	var export struct {
+		Profile      map[string]any   `json:"profile"`
+		ScoreHistory []any            `json:"score_history"`
+		Leaderboard  map[string]int64 `json:"leaderboard"`
+		QuizAttempts []map[string]any `json:"quiz_attempts"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("export is not JSON: %v\n%s", err, data)
+	}
+	if export.Profile != nil || export.ScoreHistory == nil || len(export.ScoreHistory) != 0 {
+		t.Errorf("expected empty profile sections, got %v and %v", export.Profile, export.ScoreHistory)
+	}
+	if export.Leaderboard["score"] != 7 || len(export.QuizAttempts) != 1 || export.QuizAttempts[0]["session_id"] != "s1" {
+		t.Errorf("expected the quiz data, got %s", data)
+	}
+}
+
+func TestExportJobsSweep(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e := newExportJobs(ctx)
+	build := func(ctx context.Context) ([]byte, error) { return []byte("{}"), nil }
+	done, err := e.start("user1", "json", build)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-done.done
+	running, err := e.start("user2", "json", func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		wantDone    bool
+		wantRunning bool
+	}{
+		{"within the ttl", time.Now(), true, true},
+		{"after the ttl", time.Now().Add(exportTTL + time.Minute), false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e.sweep(tt.now)
+			if _, ok := e.jobs[done.id]; ok != tt.wantDone {
+				t.Errorf("finished export kept = %v, want %v", ok, tt.wantDone)
+			}
+			if _, ok := e.jobs[running.id]; ok != tt.wantRunning {
+				t.Errorf("running export kept = %v, want %v", ok, tt.wantRunning)
+			}
+		})
+	}
+}
+
+func TestExportJobsStart(t *testing.T) {
+	ok := func(ctx context.Context) ([]byte, error) { return []byte("{}"), nil }
+	failed := func(ctx context.Context) ([]byte, error) { return nil, errors.New("backend down") }
+	running := func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	tests := []struct {
+		name      string
+		first     func(ctx context.Context) ([]byte, error)
+		age       time.Duration
+		finished  bool
+		format    string
+		wantReuse bool
+	}{
+		{"running export", running, 0, false, "json", true},
+		{"finished export", ok, 0, true, "json", true},
+		{"expired export", ok, exportTTL + time.Minute, true, "json", false},
+		{"failed export", failed, 0, true, "json", false},
+		{"other format", ok, 0, true, "zip", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &exportJobs{jobs: make(map[string]*exportJob)}
+			first, err := e.start("user1", "json", tt.first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.finished {
+				<-first.done
+			}
+			first.started = first.started.Add(-tt.age)
+
+			second, err := e.start("user1", tt.format, ok)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reused := second == first; reused != tt.wantReuse {
+				t.Errorf("start() reused the export = %v, want %v", reused, tt.wantReuse)
+			}
+			// A user keeps one export per format
+			want := 1
+			if tt.format != "json" {
+				want = 2
+			}
+			if len(e.jobs) != want {
+				t.Errorf("start() kept %d exports, want %d", len(e.jobs), want)
+			}
+		})
+	}
+}
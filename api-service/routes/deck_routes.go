@@ -0,0 +1,168 @@
+package routes
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Cprime50/api-service/client"
+	"github.com/gin-gonic/gin"
+)
+
+type AssignDeckInput struct {
+	IDs    []int64 `json:"ids"`
+	Remove bool    `json:"remove"`
+}
+
+type AssignTagsInput struct {
+	IDs    []int64  `json:"ids"`
+	Tags   []string `json:"tags"`
+	Remove bool     `json:"remove"`
+}
+
+// deckID reads the deck id from the path, it is false when it is not an id.
+func deckID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *Handler) ListDecks(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	decks, err := h.clients.Quiz.ListDecks(ctx)
+	if err != nil {
+		log.Println("Error fetching decks:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, decks)
+}
+
+func (h *Handler) CreateDeck(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	var deck client.Deck
+	if err := c.BindJSON(&deck); err != nil {
+		log.Print("error binding data for createDeck: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+	if deck.Id != 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "New decks can't have an id"})
+		return
+	}
+
+	res, err := h.clients.Quiz.CreateUpdateDeck(ctx, deck)
+	if err != nil {
+		log.Println("Error creating deck:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) UpdateDeck(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	id, ok := deckID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid deck id"})
+		return
+	}
+	var deck client.Deck
+	if err := c.BindJSON(&deck); err != nil {
+		log.Print("error binding data for updateDeck: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+	deck.Id = id
+
+	res, err := h.clients.Quiz.CreateUpdateDeck(ctx, deck)
+	if err != nil {
+		log.Println("Error updating deck:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteDeck(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	id, ok := deckID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid deck id"})
+		return
+	}
+
+	if err := h.clients.Quiz.DeleteDeck(ctx, id); err != nil {
+		log.Println("Error deleting deck:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) AssignDeck(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	id, ok := deckID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid deck id"})
+		return
+	}
+	var input AssignDeckInput
+	if err := c.BindJSON(&input); err != nil {
+		log.Print("error binding data for assignDeck: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+
+	if err := h.clients.Quiz.AssignDeck(ctx, id, input.IDs, input.Remove); err != nil {
+		log.Println("Error assigning deck:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) ListTags(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	tags, err := h.clients.Quiz.ListTags(ctx)
+	if err != nil {
+		log.Println("Error fetching tags:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, tags)
+}
+
+func (h *Handler) AssignTags(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	var input AssignTagsInput
+	if err := c.BindJSON(&input); err != nil {
+		log.Print("error binding data for assignTags: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+
+	if err := h.clients.Quiz.AssignTags(ctx, input.IDs, input.Tags, input.Remove); err != nil {
+		log.Println("Error assigning tags:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"context"
+
+	"github.com/Cprime50/api-service/client"
+	"github.com/Cprime50/api-service/storage"
+)
+
+// Handler serves the HTTP routes with the backend clients created at
+// startup and the store uploads are kept in. It also tracks the data
+// exports running in the background.
+type Handler struct {
+	clients *client.Registry
+	blobs   storage.BlobStore
+	exports *exportJobs
+}
+
+// NewHandler returns a Handler whose expired data exports are swept from
+// memory until ctx is done.
+func NewHandler(ctx context.Context, clients *client.Registry, blobs storage.BlobStore) *Handler {
+	return &Handler{clients: clients, blobs: blobs, exports: newExportJobs(ctx)}
+}
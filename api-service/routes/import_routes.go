@@ -0,0 +1,119 @@
+package routes
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	quizpb "github.com/Cprime50/api-service/quizpb"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// transferTimeout bounds quiz file uploads and downloads, which take
+	// longer than the other calls.
+	transferTimeout = time.Minute
+	// maxUploadSize is the largest quiz file upload, multipart headers
+	// included.
+	maxUploadSize = 16 << 20
+)
+
+type fileType struct {
+	format      quizpb.FileFormat
+	extension   string
+	contentType string
+}
+
+var fileTypes = map[string]fileType{
+	"csv":   {quizpb.FileFormat_CSV, ".csv", "text/csv; charset=utf-8"},
+	"jsonl": {quizpb.FileFormat_JSONL, ".jsonl", "application/x-ndjson"},
+	"anki":  {quizpb.FileFormat_ANKI_TSV, ".txt", "text/plain; charset=utf-8"},
+}
+
+// uploadFileType returns the file type named by the format parameter, or the
+// one matching the extension of filename without it.
+func uploadFileType(format, filename string) (fileType, bool) {
+	if format != "" {
+		t, ok := fileTypes[format]
+		return t, ok
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, t := range fileTypes {
+		if t.extension == ext {
+			return t, true
+		}
+	}
+	return fileType{}, false
+}
+
+func (h *Handler) ImportQuizzes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, transferTimeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize)
+	header, err := c.FormFile("file")
+	if err != nil {
+		log.Print("error reading upload for importQuizzes:", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing or too large file"})
+		return
+	}
+	t, ok := uploadFileType(c.Query("format"), header.Filename)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid format"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		log.Print("error opening upload for importQuizzes:", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid file"})
+		return
+	}
+	defer file.Close()
+
+	res, err := h.clients.Quiz.ImportQuizzes(ctx, uid, t.format, file)
+	if err != nil {
+		log.Println("Error importing quizzes:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) ExportQuizzes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, transferTimeout)
+	defer cancel()
+
+	format := c.DefaultQuery("format", "csv")
+	t, ok := fileTypes[format]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid format"})
+		return
+	}
+	filter, ok := quizFilter(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid deck"})
+		return
+	}
+
+	c.Header("Content-Type", t.contentType)
+	c.Header("Content-Disposition", `attachment; filename="quizzes`+t.extension+`"`)
+	err := h.clients.Quiz.ExportQuizzes(ctx, t.format, filter, c.Writer)
+	if err != nil {
+		log.Println("Error exporting quizzes:", err)
+		// Once the file has started the status can't be changed anymore
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		}
+	}
+}
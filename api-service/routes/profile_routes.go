@@ -2,35 +2,48 @@ package routes
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
-	"firebase.google.com/go/v4/auth"
-	"github.com/Cprime50/api-service/client"
 	"github.com/Cprime50/api-service/middleware"
+	profilepb "github.com/Cprime50/api-service/pb"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	// import middleware
-	// import client
 )
 
 var (
 	timeout = time.Second
 )
 
-func RegisterProfileRoutes(r *gin.Engine, client *auth.Client) {
+func RegisterProfileRoutes(r *gin.Engine, authenticator middleware.Authenticator, h *Handler) {
 
 	routes := r.Group("/profile")
-	routes.Use(middleware.Auth(client))
+	routes.Use(middleware.Auth(authenticator))
 	{
-		routes.POST("/create", CreateProfile)
-		routes.PUT("/update", UpdateProfile)
-		routes.GET("/:id", GetProfileByID)
-		routes.DELETE("/delete/:id", DeleteProfile)
+		routes.POST("/create", h.CreateProfile)
+		routes.PUT("/update", h.UpdateProfile)
+		routes.POST("/avatar", h.UploadAvatar)
+		routes.GET("/username/available", h.CheckUsernameAvailable)
+		routes.GET("/me/export", h.ExportMyData)
+		routes.GET("/me/export/:id", h.GetDataExportStatus)
+		routes.GET("/me/export/:id/download", h.DownloadDataExport)
+		routes.GET("/:id", h.GetProfileByID)
+		routes.DELETE("/delete/:id", h.DeleteProfile)
 	}
-	routes.Use(middleware.Auth(client), middleware.RoleAuth("admin"))
+	routes.Use(middleware.Auth(authenticator), middleware.RequirePermission(middleware.PermProfileReadAny))
 	{
-		routes.GET("/profiles", GetProfiles)
+		routes.GET("/profiles", h.GetProfiles)
 	}
 
 	r.GET("/", func(c *gin.Context) {
@@ -39,33 +52,130 @@ func RegisterProfileRoutes(r *gin.Engine, client *auth.Client) {
 
 }
 
-func CreateProfile(c *gin.Context) {
+func (h *Handler) CreateProfile(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c, timeout)
 	defer cancel()
 
-	profile, err := client.CreateUpdateProfile(c, ctx, c.Request.Method)
+	profile, err := h.clients.Profile.CreateUpdateProfile(c, ctx, c.Request.Method)
 	if err != nil {
 		log.Println("Error creating profile:", err)
 		c.JSON(http.StatusBadRequest, gin.H{"Failed to create profile ": err})
 		return
 	}
+	c.Header("ETag", profileETag(profile))
 	c.JSON(http.StatusCreated, profile)
 }
 
-func UpdateProfile(c *gin.Context) {
+// UpdateProfile applies a JSON Merge Patch (RFC 7396) to the user's profile:
+// members set the fields they name, null clears them and absent ones are
+// left as they are. With an If-Match header the patch only applies to the
+// profile version it names, else it fails with 412.
+func (h *Handler) UpdateProfile(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c, timeout)
 	defer cancel()
 
-	profile, err := client.CreateUpdateProfile(c, ctx, c.Request.Method)
+	userValue, exists := c.Get("user")
+	user, ok := userValue.(*middleware.User)
+	if !exists || !ok || user == nil {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c.GetHeader("If-Match"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes, paths, err := profileMergePatch(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := h.clients.Profile.UpdateProfile(ctx, user, changes, paths, expectedVersion)
 	if err != nil {
 		log.Println("Error updating profile:", err)
-		c.JSON(http.StatusBadRequest, gin.H{"Failed to update profile ": err})
+		c.JSON(updateProfileStatus(err, expectedVersion), gin.H{"error": errorMessage(err)})
 		return
 	}
-	c.JSON(http.StatusCreated, profile)
+	c.Header("ETag", profileETag(profile))
+	c.JSON(http.StatusOK, profile)
+}
+
+// updateProfileStatus maps a failed profile update to an HTTP status code.
+// Under If-Match a version conflict is 412, a taken username stays 409.
+func updateProfileStatus(err error, expectedVersion int64) int {
+	code := httpStatus(err)
+	if expectedVersion != 0 && code == http.StatusConflict && status.Code(err) != codes.AlreadyExists {
+		code = http.StatusPreconditionFailed
+	}
+	return code
+}
+
+// profileETag is the entity tag of a profile, its version.
+func profileETag(p *profilepb.Profile) string {
+	return strconv.Quote(strconv.FormatInt(p.Version, 10))
+}
+
+// ifMatchVersion reads the profile version an If-Match header names, 0 when
+// there is no header or it is "*" and any version will do.
+func ifMatchVersion(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	tag, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, errors.New("Invalid If-Match header, expected a single strong ETag")
+	}
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("Invalid If-Match header, %s is not a profile ETag", header)
+	}
+	return version, nil
+}
+
+// patchableFields are the profile fields a merge patch can set, by JSON
+// member name, with how each is written.
+var patchableFields = map[string]func(p *profilepb.Profile, value string){
+	"username": func(p *profilepb.Profile, value string) { p.Username = value },
+	"bio":      func(p *profilepb.Profile, value string) { p.Bio = value },
+	"avatar":   func(p *profilepb.Profile, value string) { p.Avatar = value },
+}
+
+// profileMergePatch reads a merge patch of a profile into the changes and
+// the field mask naming them.
+func profileMergePatch(body io.Reader) (*profilepb.Profile, []string, error) {
+	var patch map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&patch); err != nil || patch == nil {
+		return nil, nil, errors.New("Invalid merge patch, expected a JSON object")
+	}
+
+	changes := &profilepb.Profile{}
+	paths := make([]string, 0, len(patch))
+	for member, raw := range patch {
+		set, ok := patchableFields[member]
+		if !ok {
+			return nil, nil, fmt.Errorf("Invalid merge patch, %q cannot be updated", member)
+		}
+		var value *string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, nil, fmt.Errorf("Invalid merge patch, %q must be a string or null", member)
+		}
+		if value != nil {
+			set(changes, *value)
+		}
+		paths = append(paths, member)
+	}
+	if len(paths) == 0 {
+		return nil, nil, errors.New("Invalid merge patch, no fields to update")
+	}
+	sort.Strings(paths)
+	return changes, paths, nil
 }
 
-func GetProfileByID(c *gin.Context) {
+func (h *Handler) GetProfileByID(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c, timeout)
 	defer cancel()
 
@@ -76,35 +186,117 @@ func GetProfileByID(c *gin.Context) {
 	}
 
 	id := c.Param("id")
-	if !isAdmin(c) && uid != id {
+	if !hasPermission(c, middleware.PermProfileReadAny) && uid != id {
 		log.Println("Error uid and id don't match, user is unauthorized to access")
 		c.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
 
-	profile, err := client.GetProfile(ctx, id)
+	profile, err := h.clients.Profile.GetProfile(ctx, id)
 	if err != nil {
 		log.Println("Error fetching profile:", err)
 		c.JSON(http.StatusBadRequest, gin.H{"Failed to fetch profile ": err})
 		return
 	}
+	c.Header("ETag", profileETag(profile))
 	c.JSON(http.StatusOK, profile)
 }
 
-func GetProfiles(c *gin.Context) {
+func (h *Handler) GetProfiles(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c, timeout)
 	defer cancel()
 
-	profiles, err := client.GetAllProfiles(ctx)
+	req, err := listProfilesRequest(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profiles, err := h.clients.Profile.GetAllProfiles(ctx, req)
 	if err != nil {
 		log.Println("Error fetching profiles:", err)
-		c.JSON(http.StatusBadRequest, gin.H{"Failed to fetch profiles ": err})
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
 		return
 	}
 	c.JSON(http.StatusOK, profiles)
 }
 
-func DeleteProfile(c *gin.Context) {
+var profileSorts = map[string]profilepb.ProfileSort{
+	"":           profilepb.ProfileSort_CREATED_AT,
+	"created_at": profilepb.ProfileSort_CREATED_AT,
+	"score":      profilepb.ProfileSort_SCORE,
+	"username":   profilepb.ProfileSort_USERNAME,
+}
+
+// listProfilesRequest reads the page, filter and sort query parameters of
+// GET /profile/profiles. Dates are RFC 3339 and order is asc or desc.
+func listProfilesRequest(c *gin.Context) (*profilepb.GetAllProfilesRequest, error) {
+	req := &profilepb.GetAllProfilesRequest{
+		PageToken:      c.Query("page_token"),
+		UsernamePrefix: strings.TrimSpace(c.Query("username_prefix")),
+	}
+	if v := c.Query("page_size"); v != "" {
+		size, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || size < 0 {
+			return nil, errors.New("Invalid page_size")
+		}
+		req.PageSize = int32(size)
+	}
+	for name, score := range map[string]**int64{"min_score": &req.MinScore, "max_score": &req.MaxScore} {
+		if v := c.Query(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid %s", name)
+			}
+			*score = &n
+		}
+	}
+	for name, date := range map[string]**timestamppb.Timestamp{"created_after": &req.CreatedAfter, "created_before": &req.CreatedBefore} {
+		if v := c.Query(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid %s", name)
+			}
+			*date = timestamppb.New(t)
+		}
+	}
+	sortBy, ok := profileSorts[c.Query("sort")]
+	if !ok {
+		return nil, errors.New("Invalid sort")
+	}
+	req.Sort = sortBy
+	switch c.Query("order") {
+	case "", "asc":
+	case "desc":
+		req.Descending = true
+	default:
+		return nil, errors.New("Invalid order")
+	}
+	return req, nil
+}
+
+// CheckUsernameAvailable answers the signup form's check of the username
+// query parameter.
+func (h *Handler) CheckUsernameAvailable(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	username := strings.TrimSpace(c.Query("username"))
+	if username == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Missing username"})
+		return
+	}
+
+	res, err := h.clients.Profile.CheckUsernameAvailable(ctx, username)
+	if err != nil {
+		log.Println("Error checking username:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteProfile(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c, timeout)
 	defer cancel()
 
@@ -114,12 +306,12 @@ func DeleteProfile(c *gin.Context) {
 		return
 	}
 	id := c.Param("id")
-	if !isAdmin(c) && uid != id {
+	if !hasPermission(c, middleware.PermProfileDeleteAny) && uid != id {
 		log.Println("Error uid and id don't match, user is unauthorized to access")
 		c.AbortWithStatus(http.StatusUnauthorized)
 		return
 	}
-	err := client.DeleteProfile(ctx, id)
+	err := h.clients.Profile.DeleteProfile(ctx, id)
 	if err != nil {
 		log.Println("Error deleting profiles:", err)
 		c.JSON(http.StatusBadRequest, gin.H{"Failed to delete profile ": err})
@@ -138,11 +330,13 @@ func getAuthUserID(ctx *gin.Context) (string, bool) {
 	return userID, true
 }
 
-func isAdmin(ctx *gin.Context) bool {
+// hasPermission reports whether the role of the authenticated user grants
+// permission.
+func hasPermission(ctx *gin.Context, permission middleware.Permission) bool {
 	user, exists := ctx.Get("user")
 	if !exists {
 		return false
 	}
 	role := user.(*middleware.User).Role
-	return role == "admin"
+	return middleware.HasPermission(role, permission)
 }
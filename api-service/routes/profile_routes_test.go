@@ -0,0 +1,107 @@
+package routes
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	profilepb "github.com/Cprime50/api-service/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProfileMergePatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		want      *profilepb.Profile
+		wantPaths []string
+		wantErr   bool
+	}{
+		{"set one field", `{"bio": "Hello"}`, &profilepb.Profile{Bio: "Hello"}, []string{"bio"}, false},
+		{"set fields in path order", `{"username": "neko", "avatar": "a.png"}`, &profilepb.Profile{Username: "neko", Avatar: "a.png"}, []string{"avatar", "username"}, false},
+		{"null clears the field", `{"bio": null}`, &profilepb.Profile{}, []string{"bio"}, false},
+		{"empty string clears the field", `{"avatar": ""}`, &profilepb.Profile{}, []string{"avatar"}, false},
+		{"unknown member", `{"email": "a@b.c"}`, nil, nil, true},
+		{"read only member", `{"score": "10"}`, nil, nil, true},
+		{"value not a string", `{"bio": 3}`, nil, nil, true},
+		{"no members", `{}`, nil, nil, true},
+		{"not an object", `["bio"]`, nil, nil, true},
+		{"null patch", `null`, nil, nil, true},
+		{"invalid json", `{"bio":`, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, paths, err := profileMergePatch(strings.NewReader(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("profileMergePatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !proto.Equal(changes, tt.want) {
+				t.Errorf("profileMergePatch() changes = %v, want %v", changes, tt.want)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("profileMergePatch() paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"*", 0, false},
+		{` "3" `, 3, false},
+		{`"12"`, 12, false},
+		{"3", 0, true},
+		{`W/"3"`, 0, true},
+		{`"3", "4"`, 0, true},
+		{`"0"`, 0, true},
+		{`"-1"`, 0, true},
+		{`"abc"`, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			got, err := ifMatchVersion(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ifMatchVersion(%q) error = %v, wantErr %v", tt.header, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ifMatchVersion(%q) = %d, want %d", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateProfileStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		code            codes.Code
+		expectedVersion int64
+		want            int
+	}{
+		{"stale version under If-Match", codes.FailedPrecondition, 3, http.StatusPreconditionFailed},
+		{"concurrent update under If-Match", codes.Aborted, 3, http.StatusPreconditionFailed},
+		{"username taken under If-Match", codes.AlreadyExists, 3, http.StatusConflict},
+		{"concurrent update without If-Match", codes.Aborted, 0, http.StatusConflict},
+		{"username taken without If-Match", codes.AlreadyExists, 0, http.StatusConflict},
+		{"invalid under If-Match", codes.InvalidArgument, 3, http.StatusBadRequest},
+		{"not found under If-Match", codes.NotFound, 3, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := status.Error(tt.code, "update failed")
+			if got := updateProfileStatus(err, tt.expectedVersion); got != tt.want {
+				t.Errorf("updateProfileStatus(%v, %d) = %d, want %d", tt.code, tt.expectedVersion, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,415 @@
+package routes
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Cprime50/api-service/client"
+	"github.com/Cprime50/api-service/middleware"
+	quizpb "github.com/Cprime50/api-service/quizpb"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterQuizRoutes(r *gin.Engine, authenticator middleware.Authenticator, h *Handler) {
+
+	routes := r.Group("/quiz")
+	routes.Use(middleware.Auth(authenticator))
+	{
+		routes.GET("", h.GetQuiz)
+		routes.POST("/result", h.SubmitResult)
+		routes.GET("/score", h.GetScore)
+		routes.GET("/decks", h.ListDecks)
+		routes.GET("/tags", h.ListTags)
+	}
+
+	r.GET("/leaderboard", middleware.Auth(authenticator), h.GetLeaderBoard)
+}
+
+type QuizIDsInput struct {
+	IDs []int64 `json:"ids"`
+}
+
+var quizModes = map[string]quizpb.QuizMode{
+	"":         quizpb.QuizMode_PROGRESS,
+	"progress": quizpb.QuizMode_PROGRESS,
+	"review":   quizpb.QuizMode_REVIEW,
+}
+
+var answerFormats = map[string]quizpb.AnswerFormat{
+	"":       quizpb.AnswerFormat_CHOICE,
+	"choice": quizpb.AnswerFormat_CHOICE,
+	"typed":  quizpb.AnswerFormat_TYPED,
+}
+
+var directions = map[string]quizpb.Direction{
+	"":        quizpb.Direction_JA_EN,
+	"ja-en":   quizpb.Direction_JA_EN,
+	"reading": quizpb.Direction_JA_READING,
+	"en-ja":   quizpb.Direction_EN_JA,
+	"mixed":   quizpb.Direction_MIXED,
+}
+
+var leaderBoardWindows = map[string]quizpb.LeaderBoardWindow{
+	"":     quizpb.LeaderBoardWindow_ALL_TIME,
+	"all":  quizpb.LeaderBoardWindow_ALL_TIME,
+	"week": quizpb.LeaderBoardWindow_WEEK,
+	"day":  quizpb.LeaderBoardWindow_DAY,
+}
+
+// quizFilter reads the deck and tag query parameters, it is false when the
+// deck is not an id.
+func quizFilter(c *gin.Context) (client.QuizFilter, bool) {
+	filter := client.QuizFilter{Tag: strings.TrimSpace(c.Query("tag"))}
+	if d := c.Query("deck"); d != "" {
+		var err error
+		filter.DeckId, err = strconv.ParseInt(d, 10, 64)
+		if err != nil || filter.DeckId <= 0 {
+			return filter, false
+		}
+	}
+	return filter, true
+}
+
+func (h *Handler) GetQuiz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var seed int64
+	if s := c.Query("seed"); s != "" {
+		var err error
+		seed, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid seed"})
+			return
+		}
+	}
+	mode, ok := quizModes[c.Query("mode")]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid mode"})
+		return
+	}
+	format, ok := answerFormats[c.Query("format")]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid format"})
+		return
+	}
+	direction, ok := directions[c.Query("direction")]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid direction"})
+		return
+	}
+	filter, ok := quizFilter(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid deck"})
+		return
+	}
+
+	quiz, err := h.clients.Quiz.GetQuiz(ctx, uid, seed, mode, format, direction, filter)
+	if err != nil {
+		log.Println("Error fetching quiz:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, quiz)
+}
+
+func (h *Handler) SubmitResult(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var result client.QuizResult
+	if err := c.BindJSON(&result); err != nil {
+		log.Print("error binding data for quiz result: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+
+	// The username is only shown on the leaderboard, grade without it if the
+	// profile can't be fetched
+	var username string
+	if profile, err := h.clients.Profile.GetProfile(ctx, uid); err == nil {
+		username = profile.Username
+	}
+
+	res, err := h.clients.Quiz.GetResult(ctx, uid, username, &result)
+	if err != nil {
+		log.Println("Error grading quiz:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) GetScore(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	score, err := h.clients.Quiz.GetScore(ctx, uid)
+	if err != nil {
+		log.Println("Error fetching score:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, score)
+}
+
+func (h *Handler) GetLeaderBoard(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	window, ok := leaderBoardWindows[c.Query("window")]
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid window"})
+		return
+	}
+	var limit int64
+	if l := c.Query("limit"); l != "" {
+		var err error
+		limit, err = strconv.ParseInt(l, 10, 32)
+		if err != nil || limit < 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+	}
+
+	leaderBoard, err := h.clients.Quiz.GetLeaderBoard(ctx, uid, window, int32(limit))
+	if err != nil {
+		log.Println("Error fetching leaderboard:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, leaderBoard)
+}
+
+func (h *Handler) GetAllQuizzes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	filter, ok := quizFilter(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid deck"})
+		return
+	}
+
+	quizzes, err := h.clients.Quiz.GetAllQuizzes(ctx, filter)
+	if err != nil {
+		log.Println("Error fetching quizzes:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, quizzes)
+}
+
+func (h *Handler) CreateQuizzes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var quizzes []client.Quiz
+	if err := c.BindJSON(&quizzes); err != nil {
+		log.Print("error binding data for createQuizzes: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+	for _, quiz := range quizzes {
+		if quiz.Id != 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "New quizzes can't have an id"})
+			return
+		}
+	}
+
+	if err := h.clients.Quiz.CreateUpdateQuiz(ctx, uid, quizzes); err != nil {
+		log.Println("Error creating quizzes:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.Status(http.StatusCreated)
+}
+
+func (h *Handler) UpdateQuizzes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var quizzes []client.Quiz
+	if err := c.BindJSON(&quizzes); err != nil {
+		log.Print("error binding data for updateQuizzes: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+	for _, quiz := range quizzes {
+		if quiz.Id == 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Quizzes to update need an id"})
+			return
+		}
+	}
+
+	if err := h.clients.Quiz.CreateUpdateQuiz(ctx, uid, quizzes); err != nil {
+		log.Println("Error updating quizzes:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) DeleteQuizzes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input QuizIDsInput
+	if err := c.BindJSON(&input); err != nil {
+		log.Print("error binding data for deleteQuizzes: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+
+	if err := h.clients.Quiz.DeleteQuiz(ctx, uid, input.IDs); err != nil {
+		log.Println("Error deleting quizzes:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) ListTrash(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	quizzes, err := h.clients.Quiz.ListTrash(ctx)
+	if err != nil {
+		log.Println("Error fetching trash:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, quizzes)
+}
+
+func (h *Handler) RestoreQuizzes(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input QuizIDsInput
+	if err := c.BindJSON(&input); err != nil {
+		log.Print("error binding data for restoreQuizzes: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+
+	if err := h.clients.Quiz.RestoreQuiz(ctx, uid, input.IDs); err != nil {
+		log.Println("Error restoring quizzes:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func (h *Handler) PurgeTrash(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	res, err := h.clients.Quiz.PurgeTrash(ctx)
+	if err != nil {
+		log.Println("Error purging trash:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) GetQuizHistory(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid quiz id"})
+		return
+	}
+
+	revisions, err := h.clients.Quiz.GetQuizHistory(ctx, id)
+	if err != nil {
+		log.Println("Error fetching quiz history:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
+
+type RevertInput struct {
+	RevisionID int64 `json:"revision_id"`
+}
+
+func (h *Handler) RevertQuiz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	uid, ok := getAuthUserID(c)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	var input RevertInput
+	if err := c.BindJSON(&input); err != nil {
+		log.Print("error binding data for revertQuiz: Invalid Json format")
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid Json format"})
+		return
+	}
+
+	quiz, err := h.clients.Quiz.RevertQuiz(ctx, uid, input.RevisionID)
+	if err != nil {
+		log.Println("Error reverting quiz:", err)
+		c.JSON(httpStatus(err), gin.H{"error": errorMessage(err)})
+		return
+	}
+	c.JSON(http.StatusOK, quiz)
+}
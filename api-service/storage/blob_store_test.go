@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// failingReader returns some data, then fails.
+type failingReader struct{ read bool }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.read {
+		return 0, errors.New("connection reset")
+	}
+	r.read = true
+	return copy(p, "partial"), nil
+}
+
+func TestLocalStorePut(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		key     string
+		body    string
+		wantURL string
+		wantErr error
+	}{
+		{"top level key", context.Background(), "a.png", "a", "/uploads/a.png", nil},
+		{"nested key", context.Background(), "avatars/abc/64.png", "64", "/uploads/avatars/abc/64.png", nil},
+		{"empty key", context.Background(), "", "x", "", ErrInvalidKey},
+		{"parent directory", context.Background(), "../x.png", "x", "", ErrInvalidKey},
+		{"absolute key", context.Background(), "/x.png", "x", "", ErrInvalidKey},
+		{"unclean key", context.Background(), "avatars//x.png", "x", "", ErrInvalidKey},
+		{"directory key", context.Background(), "avatars/", "x", "", ErrInvalidKey},
+		{"canceled", canceled, "c.png", "x", "", context.Canceled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s := NewLocalStore(dir, "/uploads/")
+			url, err := s.Put(tt.ctx, tt.key, "image/png", strings.NewReader(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Put() error = %v, want %v", err, tt.wantErr)
+			}
+			if url != tt.wantURL {
+				t.Errorf("Put() url = %q, want %q", url, tt.wantURL)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(tt.key)))
+			if err != nil {
+				t.Fatalf("blob not written: %v", err)
+			}
+			if string(data) != tt.body {
+				t.Errorf("blob = %q, want %q", data, tt.body)
+			}
+		})
+	}
+}
+
+func TestLocalStorePutReplace(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStore(dir, "/uploads")
+	name := filepath.Join(dir, "avatars", "a.png")
+
+	tests := []struct {
+		name    string
+		body    io.Reader
+		wantErr bool
+		want    string
+	}{
+		{"first write", strings.NewReader("old"), false, "old"},
+		{"replace", strings.NewReader("new"), false, "new"},
+		// A failed write never reaches the blob, the temporary file is
+		// only renamed over it once complete
+		{"failed write keeps the blob", &failingReader{}, true, "new"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Put(context.Background(), "avatars/a.png", "image/png", tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Put() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			data, err := os.ReadFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("blob = %q, want %q", data, tt.want)
+			}
+			entries, err := os.ReadDir(filepath.Dir(name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 {
+				t.Errorf("expected only the blob in its directory, got %d files", len(entries))
+			}
+			info, _ := os.Stat(name)
+			if perm := info.Mode().Perm(); perm != 0o644 {
+				t.Errorf("blob mode = %v, want 0644", perm)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// columns returns a w by h image whose pixels have their x coordinate as
+// red and their y coordinate as green, starting at origin.
+func columns(origin image.Point, w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rectangle{Min: origin, Max: origin.Add(image.Pt(w, h))})
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(origin.X+x, origin.Y+y, color.RGBA{uint8(x), uint8(y), 0, 255})
+		}
+	}
+	return img
+}
+
+func TestCropSquare(t *testing.T) {
+	tests := []struct {
+		name  string
+		img   image.Image
+		side  int
+		first color.RGBA
+	}{
+		{"square", columns(image.Point{}, 3, 3), 3, color.RGBA{0, 0, 0, 255}},
+		{"wide keeps the center columns", columns(image.Point{}, 6, 2), 2, color.RGBA{2, 0, 0, 255}},
+		{"tall keeps the center rows", columns(image.Point{}, 2, 7), 2, color.RGBA{0, 2, 0, 255}},
+		{"bounds not at the origin", columns(image.Pt(10, 20), 4, 2), 2, color.RGBA{1, 0, 0, 255}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			square := CropSquare(tt.img)
+			if got := square.Bounds(); got != image.Rect(0, 0, tt.side, tt.side) {
+				t.Fatalf("CropSquare() bounds = %v, want %dx%d at the origin", got, tt.side, tt.side)
+			}
+			if got := square.RGBAAt(0, 0); got != tt.first {
+				t.Errorf("CropSquare() first pixel = %v, want %v", got, tt.first)
+			}
+		})
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	// 4x4 whose left half is black and right half white
+	halves := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			c := color.RGBA{0, 0, 0, 255}
+			if x >= 2 {
+				c = color.RGBA{255, 255, 255, 255}
+			}
+			halves.SetRGBA(x, y, c)
+		}
+	}
+	dot := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	dot.SetRGBA(0, 0, color.RGBA{10, 20, 30, 255})
+
+	tests := []struct {
+		name string
+		src  *image.RGBA
+		size int
+		want map[image.Point]color.RGBA
+	}{
+		{"same size", halves, 4, map[image.Point]color.RGBA{{1, 0}: {0, 0, 0, 255}, {2, 3}: {255, 255, 255, 255}}},
+		{"downscale keeps each half", halves, 2, map[image.Point]color.RGBA{{0, 0}: {0, 0, 0, 255}, {1, 1}: {255, 255, 255, 255}}},
+		{"downscale averages", halves, 1, map[image.Point]color.RGBA{{0, 0}: {127, 127, 127, 255}}},
+		{"upscale repeats", dot, 3, map[image.Point]color.RGBA{{0, 0}: {10, 20, 30, 255}, {2, 2}: {10, 20, 30, 255}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnail := Thumbnail(tt.src, tt.size)
+			if got := thumbnail.Bounds(); got != image.Rect(0, 0, tt.size, tt.size) {
+				t.Fatalf("Thumbnail() bounds = %v, want %dx%d", got, tt.size, tt.size)
+			}
+			for p, want := range tt.want {
+				if got := thumbnail.RGBAAt(p.X, p.Y); got != want {
+					t.Errorf("Thumbnail() pixel %v = %v, want %v", p, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Dialect covers what differs between the SQL databases the service runs
+// on. Queries are written with $1 placeholders and SQL both databases accept.
+type Dialect interface {
+	// Name is the name migrations for the dialect are kept under.
+	Name() string
+	// IsUniqueViolation reports whether err is a unique or primary key
+	// constraint failing.
+	IsUniqueViolation(err error) bool
+	// ViolatedConstraint returns the constraint err reports failing: the
+	// index name, or table.column for a SQLite UNIQUE column. It is empty
+	// when err is not a constraint failing.
+	ViolatedConstraint(err error) string
+}
+
+var (
+	SQLite   Dialect = sqliteDialect{}
+	Postgres Dialect = postgresDialect{}
+)
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return "sqlite" }
+
+func (sqliteDialect) IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint &&
+		(sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+}
+
+func (sqliteDialect) ViolatedConstraint(err error) string {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code != sqlite3.ErrConstraint {
+		return ""
+	}
+	// "UNIQUE constraint failed: profiles.email" or, for expression
+	// indexes, "UNIQUE constraint failed: index 'profiles_username_lower'"
+	_, name, _ := strings.Cut(sqliteErr.Error(), "failed: ")
+	name = strings.TrimPrefix(name, "index ")
+	return strings.Trim(name, "'")
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return "postgres" }
+
+func (postgresDialect) IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func (postgresDialect) ViolatedConstraint(err error) string {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return ""
+	}
+	return pqErr.Constraint
+}
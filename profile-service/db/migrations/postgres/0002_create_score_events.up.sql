@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS score_events (
+    idempotency_key TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    points BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS profiles_created_at;
+DROP INDEX IF EXISTS profiles_score;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS profiles_score ON profiles (score, id);
+CREATE INDEX IF NOT EXISTS profiles_created_at ON profiles (created_at, id);
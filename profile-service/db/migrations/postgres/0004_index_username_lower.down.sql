@@ -0,0 +1 @@
+DROP INDEX IF EXISTS profiles_username_lower;
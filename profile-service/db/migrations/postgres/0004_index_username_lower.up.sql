@@ -0,0 +1,13 @@
+-- Usernames differing only in case can't share the case-insensitive index.
+-- The oldest keeps its name, the others get a suffix from their id, cut
+-- like UsernameWithSuffix to stay within 30 characters.
+UPDATE profiles SET username = SUBSTR(username, 1, 21) || '_' || SUBSTR(REPLACE(id, '-', ''), 1, 8)
+WHERE id IN (
+    SELECT id FROM (
+        SELECT id, ROW_NUMBER() OVER (PARTITION BY LOWER(username) ORDER BY created_at, id) AS n
+        FROM profiles
+    ) ranked
+    WHERE n > 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS profiles_username_lower ON profiles (LOWER(username));
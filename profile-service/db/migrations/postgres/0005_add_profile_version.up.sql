@@ -0,0 +1 @@
+ALTER TABLE profiles ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
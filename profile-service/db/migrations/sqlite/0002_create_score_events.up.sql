@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS score_events (
+    idempotency_key TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    points INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
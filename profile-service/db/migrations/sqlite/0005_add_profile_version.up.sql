@@ -0,0 +1 @@
+ALTER TABLE profiles ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,128 @@
+package db
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func TestMigrateUpDown(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	migrations, err := Migrations(SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: every migration is applied once
+	applied, err := Migrate(db, SQLite)
+	if err != nil || applied != len(migrations) {
+		t.Fatalf("expected %d migrations applied, got %d, %v", len(migrations), applied, err)
+	}
+	applied, err = Migrate(db, SQLite)
+	if err != nil || applied != 0 {
+		t.Errorf("expected nothing applied twice, got %d, %v", applied, err)
+	}
+
+	// Test case 2: down reverts the latest migration and status shows it pending
+	var out bytes.Buffer
+	if err := Command(db, SQLite, []string{"down"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if err := Command(db, SQLite, []string{"status"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(migrations)+1 || !strings.HasSuffix(lines[len(lines)-1], "pending") || strings.HasSuffix(lines[1], "pending") {
+		t.Errorf("unexpected status output:\n%s", out.String())
+	}
+
+	// Test case 3: down past the first migration drops every table
+	reverted, err := MigrateDown(db, SQLite, 10)
+	if err != nil || reverted != len(migrations)-1 {
+		t.Fatalf("expected %d migrations reverted, got %d, %v", len(migrations)-1, reverted, err)
+	}
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'profiles'").Scan(&count); err != nil || count != 0 {
+		t.Errorf("expected profiles dropped, got %d, %v", count, err)
+	}
+
+	// Test case 4: unknown commands are rejected
+	if err := Command(db, SQLite, []string{"seed"}, &out); err == nil {
+		t.Error("expected error for unknown command")
+	}
+}
+
+func TestMigrationsMatch(t *testing.T) {
+	sqlite, err := Migrations(SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	postgres, err := Migrations(Postgres)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: both dialects have the same numbered migrations
+	if len(sqlite) != len(postgres) {
+		t.Fatalf("expected %d postgres migrations, got %d", len(sqlite), len(postgres))
+	}
+	for i := range sqlite {
+		if sqlite[i].Version != postgres[i].Version || sqlite[i].Name != postgres[i].Name {
+			t.Errorf("migration %d: sqlite has %04d_%s, postgres has %04d_%s",
+				i, sqlite[i].Version, sqlite[i].Name, postgres[i].Version, postgres[i].Name)
+		}
+	}
+}
+
+func TestMigrateUsernameCase(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	if _, err := Migrate(db, SQLite); err != nil {
+		t.Fatal(err)
+	}
+	// Back to before the case-insensitive index
+	if _, err := MigrateDown(db, SQLite, 2); err != nil {
+		t.Fatal(err)
+	}
+	long := strings.Repeat("a", 30)
+	profiles := [][3]string{
+		{"1b9d6bcd-bbfd-4b2d-9b5d-ab8dfbbd4bed", "Bob", "2024-01-01 00:00:00"},
+		{"7c9e6679-7425-40de-944b-e07fc1f90ae7", "bob", "2024-02-01 00:00:00"},
+		{"3f2504e0-4f89-11d3-9a0c-0305e82c3301", "BOB", "2024-03-01 00:00:00"},
+		{"a8098c1a-f86e-11da-bd1a-00112444be1e", long, "2024-01-01 00:00:00"},
+		{"6fa459ea-ee8a-3ca4-894e-db77e160355e", strings.ToUpper(long), "2024-02-01 00:00:00"},
+	}
+	for i, p := range profiles {
+		_, err := db.Exec("INSERT INTO profiles (id, user_id, email, username, created_at) VALUES ($1, $2, $3, $4, $5)",
+			p[0], p[0], p[0]+"@example.com", p[1], p[2])
+		if err != nil {
+			t.Fatalf("profile %d: %v", i, err)
+		}
+	}
+
+	// The oldest keeps its username, the others are renamed
+	if _, err := Migrate(db, SQLite); err != nil {
+		t.Fatalf("expected the migration to rename duplicates, got %v", err)
+	}
+	want := []string{"Bob", "bob_7c9e6679", "BOB_3f2504e0", long, strings.ToUpper(long[:21]) + "_6fa459ea"}
+	for i, p := range profiles {
+		var username string
+		if err := db.QueryRow("SELECT username FROM profiles WHERE id = $1", p[0]).Scan(&username); err != nil {
+			t.Fatal(err)
+		}
+		if username != want[i] {
+			t.Errorf("profile %d: username = %q, want %q", i, username, want[i])
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"log/slog"
 	"net"
+	"os"
+	"time"
 
 	"github.com/Cprime50/user/db"
 	pb "github.com/Cprime50/user/profilepb"
@@ -13,6 +15,7 @@ import (
 	"github.com/Cprime50/user/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	//"google.golang.org/grpc/reflection"
 	//"google.golang.org/grpc/reflection"
@@ -29,28 +32,46 @@ var (
 	GRPC_PORT = utils.MustHaveEnv("GRPC_PORT")
 	CERT_PATH = utils.MustHaveEnv("CERT_PATH")
 	KEY_PATH  = utils.MustHaveEnv("KEY_PATH")
+
+	// DATABASE_URL selects the database: a postgres:// URL for PostgreSQL,
+	// otherwise a SQLite file, user.db when it is not set.
+	DATABASE_URL = os.Getenv("DATABASE_URL")
 )
 
 func main() {
-	server := &src.Server{}
+	// "migrate up|down [N]|status" manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		Db, dialect, err := db.Connect(DATABASE_URL)
+		if err != nil {
+			log.Fatal("Error connecting to Db", err)
+		}
+		defer Db.Close()
+		if err := db.Command(Db, dialect, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	//Load logger
 
 	//Connect db
-	Db, err := db.Connect()
+	Db, dialect, err := db.Connect(DATABASE_URL)
 	if err != nil {
 		slog.Error("Error opening database", "db.Connect", err)
 		log.Fatal("Error connecting to Db", err)
 	}
-	log.Println("Database connected successfully")
+	log.Printf("Database connected successfully: %s", dialect.Name())
 
 	// migrations
 	log.Printf("Migrations Started")
-	err = db.Migrate(Db)
+	applied, err := db.Migrate(Db, dialect)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer db.Db.Close()
+	log.Printf("Migrations applied: %d", applied)
+	defer Db.Close()
+
+	server := src.NewServer(src.NewProfileStore(Db, dialect), slog.Default())
 
 	// Run the gRPC server
 	lis, err := net.Listen("tcp", fmt.Sprintf("%v", GRPC_PORT))
@@ -59,6 +80,12 @@ func main() {
 		panic(err)
 	}
 
+	// Let api-service keep idle connections alive with pings
+	keepalivePolicy := grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             20 * time.Second,
+		PermitWithoutStream: true,
+	})
+
 	var s *grpc.Server
 	if ENV == "production" {
 		certificate, err := tls.LoadX509KeyPair(CERT_PATH, KEY_PATH)
@@ -66,10 +93,10 @@ func main() {
 			slog.Error("Error loading TLS certificate", "tls.LoadX509KeyPair", err)
 			panic(err)
 		}
-		s = grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&certificate)))
+		s = grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&certificate)), keepalivePolicy)
 	} else {
 		//grpcLogger := grpc.UnaryInterceptor(utils.GrpcLogger)
-		s = grpc.NewServer()
+		s = grpc.NewServer(keepalivePolicy)
 	}
 
 	//}
@@ -9,7 +9,6 @@ import (
 	"github.com/Cprime50/user/db"
 	pb "github.com/Cprime50/user/profilepb"
 	"github.com/google/uuid"
-	"github.com/mattn/go-sqlite3"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -18,13 +17,54 @@ var (
 	ErrDuplicateEntry            = errors.New("duplicate entry")
 	ErrForeignKeyViolation       = errors.New("foreign key violation")
 	ErrUniqueConstraintViolation = errors.New("unique constraint violation")
+	ErrUsernameTaken             = errors.New("username is taken")
+	ErrUsernameUnavailable       = errors.New("no free username found")
+	ErrVersionConflict           = errors.New("profile was changed by another update")
 )
 
-func getProfileByUserId(userId string) (*pb.Profile, error) {
+// ProfileStore keeps profiles and the score events added to them.
+type ProfileStore interface {
+	GetProfileByUserId(userId string) (*pb.Profile, error)
+	CreateProfile(p *pb.Profile) error
+	// UpdateProfile writes p over the profile when it is still at
+	// p.Version, failing with ErrVersionConflict otherwise. A zero version
+	// skips the check.
+	UpdateProfile(p *pb.Profile) error
+	// SelectProfiles returns up to limit profiles matching filter in order,
+	// starting after the cursor when it is not nil.
+	SelectProfiles(filter profileFilter, order profileOrder, after *profileCursor, limit int) ([]*pb.Profile, error)
+	CountProfiles(filter profileFilter) (int64, error)
+	// UsernameTaken reports whether a profile has username, in any case.
+	UsernameTaken(username string) (bool, error)
+	DeleteProfileByUserId(userId string) error
+	UpdateScore(userId string, score int64) error
+	// AddScore adds points to the user's score once per idempotencyKey and
+	// returns the resulting score. applied is false when the key was
+	// already used, in which case the score is left untouched.
+	AddScore(userId string, points int64, idempotencyKey string) (score int64, applied bool, err error)
+	// ScoreEvents returns the score events added to the user, oldest
+	// first.
+	ScoreEvents(userId string) ([]*pb.ScoreEvent, error)
+}
+
+// sqlStore is the ProfileStore on SQLite and PostgreSQL, dialect covering
+// how the two differ.
+type sqlStore struct {
+	db      *sql.DB
+	dialect db.Dialect
+}
+
+// NewProfileStore returns the ProfileStore kept in conn, a database of the
+// given dialect.
+func NewProfileStore(conn *sql.DB, dialect db.Dialect) ProfileStore {
+	return &sqlStore{db: conn, dialect: dialect}
+}
+
+func (s *sqlStore) GetProfileByUserId(userId string) (*pb.Profile, error) {
 	profile := pb.Profile{}
 	var createdAt, updatedAt time.Time
-	err := db.Db.QueryRow("SELECT id, user_id, email, username, bio, avatar, score, created_at, updated_at FROM profiles WHERE user_id = $1", userId).
-		Scan(&profile.Id, &profile.UserId, &profile.Email, &profile.Username, &profile.Bio, &profile.Avatar, &profile.Score, &createdAt, &updatedAt)
+	err := s.db.QueryRow("SELECT id, user_id, email, username, bio, avatar, score, version, created_at, updated_at FROM profiles WHERE user_id = $1", userId).
+		Scan(&profile.Id, &profile.UserId, &profile.Email, &profile.Username, &profile.Bio, &profile.Avatar, &profile.Score, &profile.Version, &createdAt, &updatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrProfileNotFound
@@ -40,31 +80,55 @@ func getProfileByUserId(userId string) (*pb.Profile, error) {
 
 }
 
-func updateProfile(p *pb.Profile) error {
-	result, err := db.Db.Exec(
-		"UPDATE profiles SET username = $1, bio = $2, avatar = $3, updated_at = $4 WHERE user_id = $5",
+func (s *sqlStore) UpdateProfile(p *pb.Profile) error {
+	result, err := s.db.Exec(
+		"UPDATE profiles SET username = $1, bio = $2, avatar = $3, updated_at = $4, version = version + 1 WHERE user_id = $5 AND (CAST($6 AS BIGINT) = 0 OR version = $6)",
 		p.Username,
 		p.Bio,
 		p.Avatar,
 		time.Now(),
 		p.UserId,
+		p.Version,
 	)
 	if err != nil {
+		if s.dialect.IsUniqueViolation(err) {
+			return s.duplicateErr(err)
+		}
 		return fmt.Errorf("UpdateProfile error: %w", err)
 	}
 	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected == 0 {
-		return ErrProfileNotFound
+		if _, err := s.GetProfileByUserId(p.UserId); err != nil {
+			return err
+		}
+		return ErrVersionConflict
 	}
 	return nil
 }
 
-func createProfile(p *pb.Profile) error {
+// usernameConstraints are the constraints keeping usernames unique, the
+// column itself and profiles_username_lower ignoring case.
+var usernameConstraints = map[string]bool{
+	"profiles_username_lower": true,
+	"profiles.username":       true, // SQLite
+	"profiles_username_key":   true, // PostgreSQL
+}
+
+// duplicateErr returns ErrUsernameTaken when the unique violation err is on
+// the username, ErrDuplicateEntry otherwise.
+func (s *sqlStore) duplicateErr(err error) error {
+	if usernameConstraints[s.dialect.ViolatedConstraint(err)] {
+		return ErrUsernameTaken
+	}
+	return ErrDuplicateEntry
+}
+
+func (s *sqlStore) CreateProfile(p *pb.Profile) error {
 	id, err := uuid.NewRandom()
 	if err != nil {
 		return fmt.Errorf("uuid.NewRandom: %w", err)
 	}
-	_, err = db.Db.Exec(
+	_, err = s.db.Exec(
 		"INSERT INTO profiles (id, user_id, email, username, avatar, bio, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)",
 		id,
 		p.UserId,
@@ -72,20 +136,25 @@ func createProfile(p *pb.Profile) error {
 		p.Username,
 		p.Avatar,
 		p.Bio,
-		time.Now(),
+		time.Now().UTC(),
 	)
 	if err != nil {
-		sqliteErr, _ := err.(sqlite3.Error)
-		if sqliteErr.Code == sqlite3.ErrConstraint {
-			return ErrDuplicateEntry
+		if s.dialect.IsUniqueViolation(err) {
+			return s.duplicateErr(err)
 		}
 		return fmt.Errorf("CreateProfile error: %w", err)
 	}
 	return nil
 }
 
-func selectProfiles() ([]*pb.Profile, error) {
-	rows, err := db.Db.Query("SELECT * FROM profiles")
+func (s *sqlStore) SelectProfiles(filter profileFilter, order profileOrder, after *profileCursor, limit int) ([]*pb.Profile, error) {
+	var args []any
+	cond := filter.conditions(&args)
+	if after != nil {
+		cond += order.after(after, &args)
+	}
+	args = append(args, limit)
+	rows, err := s.db.Query(fmt.Sprintf("SELECT id, user_id, email, username, bio, avatar, score, version, created_at, updated_at FROM profiles WHERE TRUE%s ORDER BY %s LIMIT $%d", cond, order.clause(), len(args)), args...)
 	if err != nil {
 		return nil, fmt.Errorf("db.Query: %w", err)
 	}
@@ -96,7 +165,7 @@ func selectProfiles() ([]*pb.Profile, error) {
 	for rows.Next() {
 		profile := &pb.Profile{}
 
-		if err := rows.Scan(&profile.Id, &profile.UserId, &profile.Email, &profile.Username, &profile.Bio, &profile.Avatar, &profile.Score, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&profile.Id, &profile.UserId, &profile.Email, &profile.Username, &profile.Bio, &profile.Avatar, &profile.Score, &profile.Version, &createdAt, &updatedAt); err != nil {
 			return nil, fmt.Errorf("rows.Scan: %w", err)
 		}
 		// Convert time.Time to timestamppb.Timestamp
@@ -115,8 +184,28 @@ func selectProfiles() ([]*pb.Profile, error) {
 	return profiles, nil
 }
 
-func deleteProfileByUserId(UserID string) error {
-	result, err := db.Db.Exec("DELETE FROM profiles WHERE user_id = $1", UserID)
+func (s *sqlStore) CountProfiles(filter profileFilter) (int64, error) {
+	var args []any
+	cond := filter.conditions(&args)
+	var count int64
+	err := s.db.QueryRow("SELECT COUNT(*) FROM profiles WHERE TRUE"+cond, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("db.QueryRow: %w", err)
+	}
+	return count, nil
+}
+
+func (s *sqlStore) UsernameTaken(username string) (bool, error) {
+	var taken bool
+	err := s.db.QueryRow("SELECT EXISTS (SELECT 1 FROM profiles WHERE LOWER(username) = LOWER($1))", username).Scan(&taken)
+	if err != nil {
+		return false, fmt.Errorf("db.QueryRow: %w", err)
+	}
+	return taken, nil
+}
+
+func (s *sqlStore) DeleteProfileByUserId(userId string) error {
+	result, err := s.db.Exec("DELETE FROM profiles WHERE user_id = $1", userId)
 	if err != nil {
 		return fmt.Errorf("error deleting profile: %v", err)
 	}
@@ -127,8 +216,8 @@ func deleteProfileByUserId(UserID string) error {
 	return nil
 }
 
-func updateScore(userId string, score int64) error {
-	result, err := db.Db.Exec("UPDATE profiles SET score = $1 WHERE user_id = $2", score, userId)
+func (s *sqlStore) UpdateScore(userId string, score int64) error {
+	result, err := s.db.Exec("UPDATE profiles SET score = $1 WHERE user_id = $2", score, userId)
 	if err != nil {
 		return fmt.Errorf("error updating score: %v", err)
 	}
@@ -138,3 +227,69 @@ func updateScore(userId string, score int64) error {
 	}
 	return nil
 }
+
+func (s *sqlStore) AddScore(userId string, points int64, idempotencyKey string) (score int64, applied bool, err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, false, fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		"INSERT INTO score_events (idempotency_key, user_id, points, created_at) VALUES ($1, $2, $3, $4) ON CONFLICT (idempotency_key) DO NOTHING",
+		idempotencyKey,
+		userId,
+		points,
+		time.Now(),
+	)
+	if err != nil {
+		return 0, false, fmt.Errorf("error recording score event: %v", err)
+	}
+	inserted, _ := result.RowsAffected()
+
+	if inserted > 0 {
+		result, err = tx.Exec("UPDATE profiles SET score = score + $1 WHERE user_id = $2", points, userId)
+		if err != nil {
+			return 0, false, fmt.Errorf("error adding score: %v", err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return 0, false, ErrProfileNotFound
+		}
+	}
+
+	err = tx.QueryRow("SELECT score FROM profiles WHERE user_id = $1", userId).Scan(&score)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, ErrProfileNotFound
+		}
+		return 0, false, fmt.Errorf("error getting score: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, false, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return score, inserted > 0, nil
+}
+
+func (s *sqlStore) ScoreEvents(userId string) ([]*pb.ScoreEvent, error) {
+	rows, err := s.db.Query("SELECT idempotency_key, points, created_at FROM score_events WHERE user_id = $1 ORDER BY created_at, idempotency_key", userId)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*pb.ScoreEvent{}
+	for rows.Next() {
+		event := &pb.ScoreEvent{}
+		var createdAt time.Time
+		if err := rows.Scan(&event.IdempotencyKey, &event.Points, &createdAt); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		event.CreatedAt = timestamppb.New(createdAt)
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return events, nil
+}
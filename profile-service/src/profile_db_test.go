@@ -1,56 +1,52 @@
 package src
 
 import (
-	"log"
+	"errors"
 	"testing"
 
-	"github.com/Cprime50/user/db"
 	pb "github.com/Cprime50/user/profilepb"
 )
 
-var profiles = []pb.Profile{
-	{
-		UserId:   "test1",
-		Email:    "test1@email.com",
-		Username: "Username1",
-		Bio:      "test bio 1",
-		Avatar:   "testavater1",
-		Score:    17,
-	},
-	{
-		UserId:   "test2",
-		Email:    "test2@email.com",
-		Username: "Username2",
-		Bio:      "test bio 2",
-		Avatar:   "testavater2",
-		Score:    20,
-	},
-	{
-		UserId:   "test3",
-		Email:    "test3@email.com",
-		Username: "Username3",
-		Bio:      "test bio 3",
-		Avatar:   "testavater3",
-		Score:    30,
-	},
-}
-
-func clearProfiles() {
-	_, err := db.Db.Exec("delete from profiles")
-	if err != nil {
-		log.Fatal(err)
+func testProfiles() []*pb.Profile {
+	return []*pb.Profile{
+		{
+			UserId:   "test1",
+			Email:    "test1@email.com",
+			Username: "Username1",
+			Bio:      "test bio 1",
+			Avatar:   "testavater1",
+			Score:    17,
+		},
+		{
+			UserId:   "test2",
+			Email:    "test2@email.com",
+			Username: "Username2",
+			Bio:      "test bio 2",
+			Avatar:   "testavater2",
+			Score:    20,
+		},
+		{
+			UserId:   "test3",
+			Email:    "test3@email.com",
+			Username: "Username3",
+			Bio:      "test bio 3",
+			Avatar:   "testavater3",
+			Score:    30,
+		},
 	}
 }
 
 func TestGetProfileByUserId(t *testing.T) {
-	clearProfiles()
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
 	// Test case 1: Select a profile by id
-	profile := &profiles[0]
-	err := createProfile(profile)
+	profile := profiles[0]
+	err := store.CreateProfile(profile)
 	if err != nil {
 		t.Errorf("createProfile error: %v", err)
 	}
-	gottenProfile, err := getProfileByUserId(profile.UserId)
+	gottenProfile, err := store.GetProfileByUserId(profile.UserId)
 	if err != nil {
 		t.Errorf("getProfileByUserId error: %v", err)
 	}
@@ -59,21 +55,23 @@ func TestGetProfileByUserId(t *testing.T) {
 	}
 
 	// Test case 2: Select a profile by id that does not exist
-	_, err = getProfileByUserId("not_exist")
+	_, err = store.GetProfileByUserId("not_exist")
 	if err == nil {
 		t.Errorf("getProfileByUserId error: %v", err)
 	}
 }
 
 func TestInsertProfile(t *testing.T) {
-	clearProfiles()
-	profile := &profiles[0]
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	profile := profiles[0]
 	// Test case 1: Insert a valid profile
-	err := createProfile(profile)
+	err := store.CreateProfile(profile)
 	if err != nil {
 		t.Errorf("createProfile error: %v", err)
 	}
-	gottenProfile, _ := getProfileByUserId(profile.UserId)
+	gottenProfile, _ := store.GetProfileByUserId(profile.UserId)
 	equal := gottenProfile.Username == profile.Username &&
 		gottenProfile.Bio == profile.Bio &&
 		gottenProfile.Avatar == profile.Avatar &&
@@ -87,30 +85,44 @@ func TestInsertProfile(t *testing.T) {
 	}
 
 	// Test case 2: Insert a second valid profile
-	err = createProfile(&profiles[1])
+	err = store.CreateProfile(profiles[1])
 	if err != nil {
 		t.Errorf("createProfile error: %v", err)
 	}
-	gottenProfile, _ = getProfileByUserId(profiles[1].UserId)
+	gottenProfile, _ = store.GetProfileByUserId(profiles[1].UserId)
 	if gottenProfile.Username != profiles[1].Username {
 		t.Errorf("createProfile error: not equal")
 	}
 
 	// Test case 3: Insert a profile that already exist
-	err = createProfile(&profiles[1])
+	err = store.CreateProfile(profiles[1])
 	if err == nil {
 		t.Errorf("creating duplicate profile error: %v", err)
 	}
+
+	// Test case 4: A username differing only in case is taken
+	err = store.CreateProfile(&pb.Profile{UserId: "test4", Email: "test4@email.com", Username: "USERNAME1"})
+	if !errors.Is(err, ErrUsernameTaken) {
+		t.Errorf("createProfile error: expected ErrUsernameTaken, got %v", err)
+	}
+
+	// Test case 5: Another unique field is a duplicate entry
+	err = store.CreateProfile(&pb.Profile{UserId: "test4", Email: profile.Email, Username: "Username4"})
+	if !errors.Is(err, ErrDuplicateEntry) {
+		t.Errorf("createProfile error: expected ErrDuplicateEntry, got %v", err)
+	}
 }
 
 func TestUpdateProfile(t *testing.T) {
-	clearProfiles()
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
 	// Test case 1: Update a valid profile
-	err := createProfile(&profiles[0])
+	err := store.CreateProfile(profiles[0])
 	if err != nil {
 		t.Errorf("createProfile error: %v", err)
 	}
-	profile, _ := getProfileByUserId(profiles[0].UserId)
+	profile, _ := store.GetProfileByUserId(profiles[0].UserId)
 	newProfile := pb.Profile{
 		Id:       profile.Id,
 		Email:    profile.Email,
@@ -119,11 +131,11 @@ func TestUpdateProfile(t *testing.T) {
 		Bio:      "New bio",
 		Avatar:   "New avatar",
 	}
-	err = updateProfile(&newProfile)
+	err = store.UpdateProfile(&newProfile)
 	if err != nil {
 		t.Errorf("updateProfile error: %v", err)
 	}
-	profile, _ = getProfileByUserId(profiles[0].UserId)
+	profile, _ = store.GetProfileByUserId(profiles[0].UserId)
 	if profile.Username != newProfile.Username {
 		t.Errorf("updateProfile error: not equal")
 	}
@@ -132,22 +144,66 @@ func TestUpdateProfile(t *testing.T) {
 	newProfile = pb.Profile{
 		Id: "not_exist",
 	}
-	err = updateProfile(&newProfile)
+	err = store.UpdateProfile(&newProfile)
 	if err == nil {
 		t.Errorf("updateProfile error: %v", err)
 	}
+
+	// Test case 3: Taking another profile's username in another case
+	_ = store.CreateProfile(profiles[1])
+	profile.Username = "USERNAME2"
+	if err := store.UpdateProfile(profile); !errors.Is(err, ErrUsernameTaken) {
+		t.Errorf("updateProfile error: expected ErrUsernameTaken, got %v", err)
+	}
+}
+
+func TestUpdateProfileVersion(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	_ = store.CreateProfile(testProfiles()[0])
+	profile, _ := store.GetProfileByUserId("test1")
+	if profile.Version != 1 {
+		t.Errorf("Expected a new profile at version 1, got %d", profile.Version)
+	}
+
+	// Test case 1: An update at the current version moves it on
+	profile.Bio = "first"
+	if err := store.UpdateProfile(profile); err != nil {
+		t.Fatalf("updateProfile error: %v", err)
+	}
+	updated, _ := store.GetProfileByUserId("test1")
+	if updated.Version != 2 {
+		t.Errorf("Expected version 2, got %d", updated.Version)
+	}
+
+	// Test case 2: An update based on an older version conflicts
+	profile.Bio = "second"
+	if err := store.UpdateProfile(profile); err != ErrVersionConflict {
+		t.Errorf("updateProfile error: expected ErrVersionConflict, got %v", err)
+	}
+	updated, _ = store.GetProfileByUserId("test1")
+	if updated.Bio != "first" {
+		t.Errorf("Expected the conflicting update to be dropped, got bio %q", updated.Bio)
+	}
+
+	// Test case 3: A profile that does not exist is still not found
+	if err := store.UpdateProfile(&pb.Profile{UserId: "not_exist", Version: 1}); err != ErrProfileNotFound {
+		t.Errorf("updateProfile error: expected ErrProfileNotFound, got %v", err)
+	}
 }
 
 func TestSelectProfiles(t *testing.T) {
-	clearProfiles()
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
 
 	// Insert profiles
-	_ = createProfile(&profiles[0])
-	_ = createProfile(&profiles[1])
-	_ = createProfile(&profiles[2])
+	_ = store.CreateProfile(profiles[0])
+	_ = store.CreateProfile(profiles[1])
+	_ = store.CreateProfile(profiles[2])
 
 	// Get profiles
-	gottenProfiles, err := selectProfiles()
+	gottenProfiles, err := store.SelectProfiles(profileFilter{}, profileOrder{}, nil, 10)
 	if err != nil {
 		t.Errorf("Error selecting profiles: %v", err)
 		return
@@ -166,3 +222,102 @@ func TestSelectProfiles(t *testing.T) {
 		}
 	}
 }
+
+func TestAddScore(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	_ = store.CreateProfile(profiles[0])
+
+	// Test case 1: Add points
+	score, applied, err := store.AddScore(profiles[0].UserId, 10, "session1")
+	if err != nil {
+		t.Fatalf("addScore error: %v", err)
+	}
+	if score != 10 || !applied {
+		t.Errorf("addScore error: expected score 10 applied, got %d %v", score, applied)
+	}
+
+	// Test case 2: Same key is only applied once
+	score, applied, err = store.AddScore(profiles[0].UserId, 10, "session1")
+	if err != nil {
+		t.Fatalf("addScore error: %v", err)
+	}
+	if score != 10 || applied {
+		t.Errorf("addScore error: expected score 10 not applied, got %d %v", score, applied)
+	}
+
+	// Test case 3: New key adds up
+	score, _, _ = store.AddScore(profiles[0].UserId, 5, "session2")
+	if score != 15 {
+		t.Errorf("addScore error: expected score 15, got %d", score)
+	}
+
+	// Test case 4: Profile that does not exist
+	_, _, err = store.AddScore("not_exist", 5, "session3")
+	if err != ErrProfileNotFound {
+		t.Errorf("addScore error: expected ErrProfileNotFound, got %v", err)
+	}
+	_, applied, _ = store.AddScore(profiles[0].UserId, 5, "session3")
+	if !applied {
+		t.Errorf("addScore error: failed call should not use up the key")
+	}
+}
+
+func TestSelectProfilesFiltered(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	for _, p := range profiles {
+		_ = store.CreateProfile(p)
+		_ = store.UpdateScore(p.UserId, p.Score)
+	}
+	userIds := func(profiles []*pb.Profile) []string {
+		var ids []string
+		for _, p := range profiles {
+			ids = append(ids, p.UserId)
+		}
+		return ids
+	}
+
+	// Test case 1: Score range and username prefix
+	minScore, maxScore := int64(18), int64(30)
+	filter := profileFilter{MinScore: &minScore, MaxScore: &maxScore, UsernamePrefix: "username"}
+	gotten, err := store.SelectProfiles(filter, profileOrder{Sort: pb.ProfileSort_SCORE, Descending: true}, nil, 10)
+	if err != nil {
+		t.Fatalf("selectProfiles error: %v", err)
+	}
+	if ids := userIds(gotten); len(ids) != 2 || ids[0] != "test3" || ids[1] != "test2" {
+		t.Errorf("Expected test3 and test2, got %v", ids)
+	}
+	count, err := store.CountProfiles(filter)
+	if err != nil || count != 2 {
+		t.Errorf("countProfiles error: expected 2, got %d %v", count, err)
+	}
+
+	// Test case 2: LIKE wildcards in the prefix match themselves
+	_, err = store.SelectProfiles(profileFilter{UsernamePrefix: "%"}, profileOrder{}, nil, 10)
+	if err != ErrProfileNotFound {
+		t.Errorf("selectProfiles error: expected ErrProfileNotFound, got %v", err)
+	}
+
+	// Test case 3: Pages continue after the cursor
+	order := profileOrder{Sort: pb.ProfileSort_USERNAME}
+	page, _ := store.SelectProfiles(profileFilter{}, order, nil, 2)
+	next, err := store.SelectProfiles(profileFilter{}, order, newProfileCursor(order, page[1]), 2)
+	if err != nil {
+		t.Fatalf("selectProfiles error: %v", err)
+	}
+	if ids := userIds(append(page, next...)); len(ids) != 3 || ids[0] != "test1" || ids[2] != "test3" {
+		t.Errorf("Expected test1 to test3 over two pages, got %v", ids)
+	}
+
+	// Test case 4: Created date range
+	order = profileOrder{}
+	page, _ = store.SelectProfiles(profileFilter{}, order, nil, 10)
+	after := page[1].CreatedAt.AsTime()
+	gotten, _ = store.SelectProfiles(profileFilter{CreatedAfter: after}, order, nil, 10)
+	if ids := userIds(gotten); len(ids) != 2 || ids[0] != "test2" {
+		t.Errorf("Expected the profiles created from test2 on, got %v", ids)
+	}
+}
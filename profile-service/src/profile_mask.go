@@ -0,0 +1,47 @@
+package src
+
+import (
+	"fmt"
+
+	pb "github.com/Cprime50/user/profilepb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// updatableFields are the fields of a profile an update mask can name, with
+// how each is copied over.
+var updatableFields = map[string]func(dst, src *pb.Profile){
+	"username": func(dst, src *pb.Profile) { dst.Username = src.Username },
+	"bio":      func(dst, src *pb.Profile) { dst.Bio = src.Bio },
+	"avatar":   func(dst, src *pb.Profile) { dst.Avatar = src.Avatar },
+}
+
+// applyUpdateMask copies the fields mask names from src to dst, empty
+// values included, so they can be cleared.
+func applyUpdateMask(dst, src *pb.Profile, mask *fieldmaskpb.FieldMask) error {
+	if len(mask.GetPaths()) == 0 {
+		return fmt.Errorf("update_mask names no fields")
+	}
+	for _, path := range mask.Paths {
+		if _, ok := updatableFields[path]; !ok {
+			return fmt.Errorf("update_mask: field %q cannot be updated", path)
+		}
+	}
+	for _, path := range mask.Paths {
+		updatableFields[path](dst, src)
+	}
+	return nil
+}
+
+// applyNonEmpty copies the fields of src that are not empty to dst, how an
+// update without a mask works.
+func applyNonEmpty(dst, src *pb.Profile) {
+	if src.Username != "" {
+		dst.Username = src.Username
+	}
+	if src.Bio != "" {
+		dst.Bio = src.Bio
+	}
+	if src.Avatar != "" {
+		dst.Avatar = src.Avatar
+	}
+}
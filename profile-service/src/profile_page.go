@@ -0,0 +1,178 @@
+package src
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/Cprime50/user/profilepb"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// profileFilter narrows the profiles GetAllProfiles lists, the zero value
+// matches every profile.
+type profileFilter struct {
+	MinScore       *int64
+	MaxScore       *int64
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	UsernamePrefix string
+}
+
+// conditions returns the filter as conditions on the profiles table, each
+// starting with AND, and appends their values to args.
+func (f profileFilter) conditions(args *[]any) string {
+	var cond strings.Builder
+	if f.MinScore != nil {
+		cond.WriteString(" AND score >= " + placeholder(args, *f.MinScore))
+	}
+	if f.MaxScore != nil {
+		cond.WriteString(" AND score <= " + placeholder(args, *f.MaxScore))
+	}
+	if !f.CreatedAfter.IsZero() {
+		cond.WriteString(" AND created_at >= " + placeholder(args, f.CreatedAfter.UTC()))
+	}
+	if !f.CreatedBefore.IsZero() {
+		cond.WriteString(" AND created_at < " + placeholder(args, f.CreatedBefore.UTC()))
+	}
+	if f.UsernamePrefix != "" {
+		pattern := likeEscaper.Replace(strings.ToLower(f.UsernamePrefix)) + "%"
+		cond.WriteString(" AND LOWER(username) LIKE " + placeholder(args, pattern) + ` ESCAPE '\'`)
+	}
+	return cond.String()
+}
+
+// likeEscaper escapes the characters LIKE gives a meaning to.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// placeholder appends value to args and returns its $N placeholder.
+func placeholder(args *[]any, value any) string {
+	*args = append(*args, value)
+	return fmt.Sprintf("$%d", len(*args))
+}
+
+// profileOrder is the order GetAllProfiles lists profiles in, ties broken
+// by id.
+type profileOrder struct {
+	Sort       pb.ProfileSort
+	Descending bool
+}
+
+var sortColumns = map[pb.ProfileSort]string{
+	pb.ProfileSort_CREATED_AT: "created_at",
+	pb.ProfileSort_SCORE:      "score",
+	pb.ProfileSort_USERNAME:   "username",
+}
+
+func (o profileOrder) clause() string {
+	direction := "ASC"
+	if o.Descending {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%s %s, id %s", sortColumns[o.Sort], direction, direction)
+}
+
+// after returns the condition selecting the profiles that come after the
+// cursor in this order, and appends its values to args.
+func (o profileOrder) after(c *profileCursor, args *[]any) string {
+	var value any
+	switch o.Sort {
+	case pb.ProfileSort_SCORE:
+		value = c.Score
+	case pb.ProfileSort_USERNAME:
+		value = c.Username
+	default:
+		value = c.CreatedAt.UTC()
+	}
+	op := ">"
+	if o.Descending {
+		op = "<"
+	}
+	column := sortColumns[o.Sort]
+	return fmt.Sprintf(" AND (%s %s %s OR (%s = %s AND id %s %s))",
+		column, op, placeholder(args, value), column, placeholder(args, value), op, placeholder(args, c.Id))
+}
+
+// profileCursor is the last profile of a page, the next page starts after
+// it. Its order is kept along so a token is not used with another one.
+type profileCursor struct {
+	Sort       pb.ProfileSort `json:"sort"`
+	Descending bool           `json:"desc,omitempty"`
+	Id         string         `json:"id"`
+	Score      int64          `json:"score,omitempty"`
+	Username   string         `json:"username,omitempty"`
+	CreatedAt  time.Time      `json:"created_at,omitempty"`
+}
+
+func newProfileCursor(order profileOrder, last *pb.Profile) *profileCursor {
+	c := &profileCursor{Sort: order.Sort, Descending: order.Descending, Id: last.Id}
+	switch order.Sort {
+	case pb.ProfileSort_SCORE:
+		c.Score = last.Score
+	case pb.ProfileSort_USERNAME:
+		c.Username = last.Username
+	default:
+		c.CreatedAt = last.CreatedAt.AsTime()
+	}
+	return c
+}
+
+// token encodes the cursor as an opaque page token.
+func (c *profileCursor) token() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// parsePageToken decodes a page token of GetAllProfiles listing in order,
+// nil when token is empty.
+func parsePageToken(token string, order profileOrder) (*profileCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	c := &profileCursor{}
+	if err := json.Unmarshal(data, c); err != nil || c.Id == "" {
+		return nil, ErrInvalidPageToken
+	}
+	if c.Sort != order.Sort || c.Descending != order.Descending {
+		return nil, fmt.Errorf("%w: sort changed between pages", ErrInvalidPageToken)
+	}
+	return c, nil
+}
+
+// listProfilesQuery reads what GetAllProfiles lists from req.
+func listProfilesQuery(req *pb.GetAllProfilesRequest) (profileFilter, profileOrder, int) {
+	filter := profileFilter{
+		MinScore:       req.MinScore,
+		MaxScore:       req.MaxScore,
+		UsernamePrefix: req.UsernamePrefix,
+	}
+	if req.CreatedAfter != nil {
+		filter.CreatedAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		filter.CreatedBefore = req.CreatedBefore.AsTime()
+	}
+	order := profileOrder{Sort: req.Sort, Descending: req.Descending}
+
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return filter, order, pageSize
+}
@@ -3,9 +3,8 @@ package src
 import (
 	"context"
 	"errors"
-	"fmt"
-	"log"
 	"log/slog"
+	"strings"
 	"time"
 
 	pb "github.com/Cprime50/user/profilepb"
@@ -16,155 +15,303 @@ import (
 
 type Server struct {
 	pb.UnimplementedProfileServiceServer
+	store  ProfileStore
+	logger *slog.Logger
+}
+
+// NewServer returns a Server keeping profiles in store and logging to
+// logger, slog.Default() when it is nil.
+func NewServer(store ProfileStore, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Server{store: store, logger: logger}
 }
 
 func (s *Server) CreateUpdateProfile(ctx context.Context, req *pb.CreateUpdateProfileRequest) (*pb.Profile, error) {
 	start := time.Now()
 	if err := validateProfile(req.Profile); err != nil {
-		log.Printf("CreateProfile error: %v", err)
+		s.logger.Error("CreateProfile error", "error", err)
 		return nil, status.Errorf(codes.InvalidArgument, "profile validation error: %v", err)
 	}
 
 	switch req.Operation {
 	case pb.Operation_CREATE:
-		existingProfile, _ := getProfileByUserId(req.Profile.UserId)
+		existingProfile, _ := s.store.GetProfileByUserId(req.Profile.UserId)
 		if existingProfile != nil {
-			log.Printf("CreateProfile error: profile already exists for user ID: %s", req.Profile.UserId)
+			s.logger.Error("CreateProfile error: profile already exists", "user_id", req.Profile.UserId)
 			return nil, status.Errorf(codes.AlreadyExists, "profile already exists")
 		}
 
-		if req.Profile.Username == "" {
+		generated := req.Profile.Username == ""
+		if generated {
 			// Generate username if not provided
-			username, err := utils.GenerateUsername(req.Profile.Email)
+			username, err := s.generateUsername(req.Profile.Email)
 			if err != nil {
-				log.Printf("CreateProfile error: generating username failed: %v", err)
+				s.logger.Error("CreateProfile error: generating username failed", "error", err)
 				return nil, status.Errorf(codes.Internal, "error generating username: %v", err)
 			}
 			req.Profile.Username = username
+		} else if err := s.checkUsername(req.Profile.Username); err != nil {
+			s.logger.Error("CreateProfile error: username not available", "username", req.Profile.Username, "error", err)
+			return nil, err
 		}
 
-		err := createProfile(req.Profile)
+		err := s.store.CreateProfile(req.Profile)
+		for attempt := 1; generated && errors.Is(err, ErrUsernameTaken) && attempt < 3; attempt++ {
+			// Another profile took the username since it was checked
+			req.Profile.Username, err = s.generateUsername(req.Profile.Email)
+			if err == nil {
+				err = s.store.CreateProfile(req.Profile)
+			}
+		}
 		if err != nil {
-			log.Printf("CreateProfile error: creating user profile failed: %v", err)
+			if errors.Is(err, ErrUsernameTaken) {
+				s.logger.Error("CreateProfile error: username taken", "username", req.Profile.Username)
+				return nil, status.Errorf(codes.AlreadyExists, "%v: %s", ErrUsernameTaken, req.Profile.Username)
+			}
+			if errors.Is(err, ErrDuplicateEntry) {
+				s.logger.Error("CreateProfile error: profile already exists", "user_id", req.Profile.UserId, "error", err)
+				return nil, status.Errorf(codes.AlreadyExists, "profile with this email already exists")
+			}
+			s.logger.Error("CreateProfile error: creating user profile failed", "error", err)
 			return nil, status.Errorf(codes.Internal, "error creating user profile: %v", err)
 		}
 
 	case pb.Operation_UPDATE:
-		existingProfile, err := getProfileByUserId(req.Profile.UserId)
+		existingProfile, err := s.store.GetProfileByUserId(req.Profile.UserId)
 		if err != nil {
-			log.Printf("UpdateProfile error: checking existing profile failed: %v", err)
+			s.logger.Error("UpdateProfile error: checking existing profile failed", "error", err)
 			return nil, status.Errorf(codes.Internal, "error checking existing profile: %v", err)
 		}
 		if existingProfile == nil {
-			log.Printf("UpdateProfile error: profile not found for user ID: %s", req.Profile.UserId)
+			s.logger.Error("UpdateProfile error: profile not found", "user_id", req.Profile.UserId)
 			return nil, status.Errorf(codes.NotFound, "profile not found for user ID: %s", req.Profile.UserId)
 		}
-		if req.Profile.Username != "" {
-			existingProfile.Username = req.Profile.Username
+		if req.ExpectedVersion != 0 && existingProfile.Version != req.ExpectedVersion {
+			s.logger.Error("UpdateProfile error: version mismatch", "user_id", req.Profile.UserId, "version", existingProfile.Version, "expected_version", req.ExpectedVersion)
+			return nil, status.Errorf(codes.FailedPrecondition, "profile is at version %d, not %d", existingProfile.Version, req.ExpectedVersion)
 		}
-		if req.Profile.Bio != "" {
-			existingProfile.Bio = req.Profile.Bio
+		oldUsername := existingProfile.Username
+		if req.UpdateMask != nil {
+			if err := applyUpdateMask(existingProfile, req.Profile, req.UpdateMask); err != nil {
+				s.logger.Error("UpdateProfile error", "error", err)
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+		} else {
+			applyNonEmpty(existingProfile, req.Profile)
 		}
-		if req.Profile.Avatar != "" {
-			existingProfile.Avatar = req.Profile.Avatar
+		if !strings.EqualFold(existingProfile.Username, oldUsername) {
+			if err := s.checkUsername(existingProfile.Username); err != nil {
+				s.logger.Error("UpdateProfile error: username not available", "username", existingProfile.Username, "error", err)
+				return nil, err
+			}
 		}
 
-		err = updateProfile(existingProfile)
+		err = s.store.UpdateProfile(existingProfile)
+		if errors.Is(err, ErrVersionConflict) {
+			s.logger.Error("UpdateProfile error: concurrent update", "user_id", req.Profile.UserId)
+			return nil, status.Errorf(codes.Aborted, "%v, retry with the current version", err)
+		}
+		if errors.Is(err, ErrUsernameTaken) {
+			s.logger.Error("UpdateProfile error: username taken", "username", existingProfile.Username)
+			return nil, status.Errorf(codes.AlreadyExists, "%v: %s", ErrUsernameTaken, existingProfile.Username)
+		}
 		if err != nil {
-			log.Printf("UpdateProfile error: updating user profile failed: %v", err)
+			s.logger.Error("UpdateProfile error: updating user profile failed", "error", err)
 			return nil, status.Errorf(codes.Internal, "error updating user profile: %v", err)
 		}
 	default:
-		log.Printf("CreateUpdateProfile error: unknown operation: %v", req.Operation)
+		s.logger.Error("CreateUpdateProfile error: unknown operation", "operation", req.Operation)
 		return nil, status.Errorf(codes.InvalidArgument, "unknown operation: %v", req.Operation)
 	}
 
-	profile, err := getProfileByUserId(req.Profile.UserId)
+	profile, err := s.store.GetProfileByUserId(req.Profile.UserId)
 	if err != nil {
 		if errors.Is(err, ErrProfileNotFound) {
-			log.Printf("CreateUpdateProfile error: profile not found for user ID: %s", req.Profile.UserId)
+			s.logger.Error("CreateUpdateProfile error: profile not found", "user_id", req.Profile.UserId)
 			return nil, status.Errorf(codes.NotFound, err.Error())
 		}
-		log.Printf("CreateUpdateProfile: failed to get profile: %s", err)
+		s.logger.Error("CreateUpdateProfile: failed to get profile", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to get profile: %s", err)
 	}
-	log.Printf("Successesfully %s"+"D profile", req.Operation)
-	slog.Info(fmt.Sprintf("operation: %s, time: %s", req.Operation, time.Since(start)))
+	s.logger.Info("CreateUpdateProfile successful", "operation", req.Operation, "user_id", req.Profile.UserId)
+	s.logger.Info("CreateUpdateProfile", "time", time.Since(start))
 	return profile, nil
 }
 
 func (s *Server) GetProfile(ctx context.Context, req *pb.GetProfileRequest) (*pb.Profile, error) {
 	start := time.Now()
 
-	profile, err := getProfileByUserId(req.UserId)
+	profile, err := s.store.GetProfileByUserId(req.UserId)
 	if err != nil {
 		if errors.Is(err, ErrProfileNotFound) {
-			log.Printf("GetProfile error: profile not found for user ID: %s", req.UserId)
+			s.logger.Error("GetProfile error: profile not found", "user_id", req.UserId)
 			return nil, status.Errorf(codes.NotFound, err.Error())
 		}
-		log.Printf("GetProfile error: failed to get profile: %s", err)
+		s.logger.Error("GetProfile error: failed to get profile", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to get profile: %s", err)
 	}
-	log.Printf("GetProfile successful: username=%s", profile.Username)
-	slog.Info("GetProfileByUserId", "time", time.Since(start))
+	s.logger.Info("GetProfile successful", "username", profile.Username)
+	s.logger.Info("GetProfileByUserId", "time", time.Since(start))
 	return profile, nil
 }
 
-func (s *Server) GetAllProfiles(req *pb.Empty, stream pb.ProfileService_GetAllProfilesServer) error {
+// GetAllProfiles returns a page of the profiles matching the request's
+// filters, with a token for the next page and how many match in total.
+func (s *Server) GetAllProfiles(ctx context.Context, req *pb.GetAllProfilesRequest) (*pb.GetAllProfilesResponse, error) {
 	start := time.Now()
+	if err := validateListProfiles(req); err != nil {
+		s.logger.Error("GetAllProfiles error", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "list profiles validation error: %v", err)
+	}
+	filter, order, pageSize := listProfilesQuery(req)
+	after, err := parsePageToken(req.PageToken, order)
+	if err != nil {
+		s.logger.Error("GetAllProfiles error", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
 
-	profiles, err := selectProfiles()
+	// One profile more than the page tells whether there is a next page
+	profiles, err := s.store.SelectProfiles(filter, order, after, pageSize+1)
 	if err != nil {
 		if errors.Is(err, ErrProfileNotFound) {
-			log.Printf("GetAllProfiles error: profiles not found")
-			return status.Errorf(codes.NotFound, err.Error())
+			s.logger.Error("GetAllProfiles error: profiles not found")
+			return nil, status.Errorf(codes.NotFound, err.Error())
 		}
-		log.Printf("GetAllProfiles error: failed to get profiles: %s", err)
-		return status.Errorf(codes.Internal, "failed to get profiles: %s", err)
+		s.logger.Error("GetAllProfiles error: failed to get profiles", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get profiles: %s", err)
+	}
+	total, err := s.store.CountProfiles(filter)
+	if err != nil {
+		s.logger.Error("GetAllProfiles error: failed to count profiles", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to count profiles: %s", err)
+	}
+
+	res := &pb.GetAllProfilesResponse{Profiles: profiles, TotalCount: total}
+	if len(profiles) > pageSize {
+		res.Profiles = profiles[:pageSize]
+		res.NextPageToken = newProfileCursor(order, res.Profiles[pageSize-1]).token()
 	}
-	// Stream profiles to the client
-	for _, profile := range profiles {
-		// Send the profile to the client stream
-		if err := stream.Send(profile); err != nil {
-			log.Printf("GetAllProfiles error: failed to send profiles to client: %s", err)
-			return status.Errorf(codes.Internal, "failed to send profiles to client: %s", err)
+	s.logger.Info("GetAllProfiles successful: sent profiles", "count", len(res.Profiles), "total", total)
+	s.logger.Info("GetAllProfiles", "time", time.Since(start))
+	return res, nil
+}
+
+// CheckUsernameAvailable tells the signup form whether a username can be
+// chosen, suggesting a free one when it is taken.
+func (s *Server) CheckUsernameAvailable(ctx context.Context, req *pb.CheckUsernameAvailableRequest) (*pb.CheckUsernameAvailableResponse, error) {
+	start := time.Now()
+
+	res := &pb.CheckUsernameAvailableResponse{Available: true}
+	if err := utils.ValidateUsername(req.Username); err != nil {
+		res.Available = false
+		res.Reason = err.Error()
+		s.logger.Info("CheckUsernameAvailable", "time", time.Since(start))
+		return res, nil
+	}
+	taken, err := s.store.UsernameTaken(req.Username)
+	if err != nil {
+		s.logger.Error("CheckUsernameAvailable error: failed to check username", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to check username: %v", err)
+	}
+	if taken {
+		res.Available = false
+		res.Reason = ErrUsernameTaken.Error()
+		res.Suggestion, err = s.freeUsername(req.Username)
+		if err != nil && !errors.Is(err, ErrUsernameUnavailable) {
+			s.logger.Error("CheckUsernameAvailable error: failed to suggest username", "error", err)
+			return nil, status.Errorf(codes.Internal, "failed to suggest username: %v", err)
 		}
 	}
-	log.Printf("GetAllProfiles successful: sent %d profiles", len(profiles))
-	slog.Info("GetAllProfiles", "time", time.Since(start))
-	return nil
+	s.logger.Info("CheckUsernameAvailable successful", "username", req.Username, "available", res.Available)
+	s.logger.Info("CheckUsernameAvailable", "time", time.Since(start))
+	return res, nil
 }
 
 func (s *Server) DeleteProfile(ctx context.Context, req *pb.DeleteProfileRequest) (*pb.Empty, error) {
 	start := time.Now()
 
-	err := deleteProfileByUserId(req.UserId)
+	err := s.store.DeleteProfileByUserId(req.UserId)
 	if err != nil {
 		if errors.Is(err, ErrProfileNotFound) {
-			log.Printf("DeleteProfile error: profile not found for user ID: %s", req.UserId)
+			s.logger.Error("DeleteProfile error: profile not found", "user_id", req.UserId)
 			return nil, status.Errorf(codes.NotFound, "profile not found")
 		}
-		log.Printf("DeleteProfile error: failed to delete profile: %v", err)
+		s.logger.Error("DeleteProfile error: failed to delete profile", "error", err)
 		return nil, status.Errorf(codes.Internal, "error deleting profile: %v", err)
 	}
-	log.Printf("DeleteProfile successful: profile deleted for user ID: %s", req.UserId)
-	slog.Info("DeleteProfile", "time", time.Since(start))
+	s.logger.Info("DeleteProfile successful: profile deleted", "user_id", req.UserId)
+	s.logger.Info("DeleteProfile", "time", time.Since(start))
 	return &pb.Empty{}, nil
 }
 
 func (s *Server) UpdateScore(ctx context.Context, req *pb.UpdateScoreRequest) (*pb.Empty, error) {
 	start := time.Now()
 
-	err := updateScore(req.UserId, req.Score)
+	err := s.store.UpdateScore(req.UserId, req.Score)
 	if err != nil {
 		if errors.Is(err, ErrProfileNotFound) {
-			log.Printf("UpdateScore error: profile not found for user ID: %s", req.UserId)
+			s.logger.Error("UpdateScore error: profile not found", "user_id", req.UserId)
 			return nil, status.Errorf(codes.NotFound, "profile not found")
 		}
-		log.Printf("UpdateScore error: failed to update score: %v", err)
+		s.logger.Error("UpdateScore error: failed to update score", "error", err)
 		return nil, status.Errorf(codes.Internal, "failed to update score: %v", err)
 	}
-	log.Printf("UpdateScore successful: score updated for user ID: %s", req.UserId)
-	slog.Info("UpdateScore", "time", time.Since(start))
+	s.logger.Info("UpdateScore successful: score updated", "user_id", req.UserId)
+	s.logger.Info("UpdateScore", "time", time.Since(start))
 	return &pb.Empty{}, nil
 }
+
+func (s *Server) AddScore(ctx context.Context, req *pb.AddScoreRequest) (*pb.AddScoreResponse, error) {
+	start := time.Now()
+	if err := validateAddScore(req); err != nil {
+		s.logger.Error("AddScore error", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "add score validation error: %v", err)
+	}
+
+	score, applied, err := s.store.AddScore(req.UserId, req.Points, req.IdempotencyKey)
+	if err != nil {
+		if errors.Is(err, ErrProfileNotFound) {
+			s.logger.Error("AddScore error: profile not found", "user_id", req.UserId)
+			return nil, status.Errorf(codes.NotFound, "profile not found")
+		}
+		s.logger.Error("AddScore error: failed to add score", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to add score: %v", err)
+	}
+	if applied {
+		s.logger.Info("AddScore successful: added points", "points", req.Points, "user_id", req.UserId)
+	} else {
+		s.logger.Info("AddScore skipped: key already applied", "idempotency_key", req.IdempotencyKey, "user_id", req.UserId)
+	}
+	s.logger.Info("AddScore", "time", time.Since(start))
+	return &pb.AddScoreResponse{Score: score, Applied: applied}, nil
+}
+
+// ExportMyData returns everything kept about a user, for them to take a
+// copy of.
+func (s *Server) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.ProfileExport, error) {
+	start := time.Now()
+	if req.UserId == "" {
+		s.logger.Error("ExportMyData error: userId is required")
+		return nil, status.Errorf(codes.InvalidArgument, "userId is required")
+	}
+
+	profile, err := s.store.GetProfileByUserId(req.UserId)
+	if err != nil {
+		if errors.Is(err, ErrProfileNotFound) {
+			s.logger.Error("ExportMyData error: profile not found", "user_id", req.UserId)
+			return nil, status.Errorf(codes.NotFound, "profile not found")
+		}
+		s.logger.Error("ExportMyData error: failed to get profile", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get profile: %v", err)
+	}
+	events, err := s.store.ScoreEvents(req.UserId)
+	if err != nil {
+		s.logger.Error("ExportMyData error: failed to get score events", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get score events: %v", err)
+	}
+	s.logger.Info("ExportMyData successful: data exported", "user_id", req.UserId, "score_events", len(events))
+	s.logger.Info("ExportMyData", "time", time.Since(start))
+	return &pb.ProfileExport{Profile: profile, ScoreEvents: events}, nil
+}
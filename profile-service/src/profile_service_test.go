@@ -2,21 +2,26 @@ package src
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	pb "github.com/Cprime50/user/profilepb"
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestCreateUpdateProfile(t *testing.T) {
-
-	clearProfiles()
-	s := &Server{}
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	s := NewServer(store, nil)
 
 	// Test case 1: CREATE operation
 	reqCreate := &pb.CreateUpdateProfileRequest{
 		Operation: pb.Operation_CREATE,
-		Profile:   &profiles[0],
+		Profile:   profiles[0],
 	}
 	profile, err := s.CreateUpdateProfile(context.Background(), reqCreate)
 	if err != nil {
@@ -74,7 +79,7 @@ func TestCreateUpdateProfile(t *testing.T) {
 		Id:       profile.Id,
 		Email:    profile.Email,
 		UserId:   profile.UserId,
-		Username: "New username",
+		Username: "New_username",
 		Bio:      "New bio",
 		Avatar:   "New avatar",
 	}
@@ -96,13 +101,140 @@ func TestCreateUpdateProfile(t *testing.T) {
 	//Check update cannot change score
 }
 
+func TestCreateProfileUsernames(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	create := func(userId, email, username string) (*pb.Profile, error) {
+		req := &pb.CreateUpdateProfileRequest{
+			Operation: pb.Operation_CREATE,
+			Profile:   &pb.Profile{UserId: userId, Email: email, Username: username},
+		}
+		return s.CreateUpdateProfile(context.Background(), req)
+	}
+
+	// Test case 1: Emails with the same local part get different usernames
+	first, err := create("test1", "john.doe@a.com", "")
+	if err != nil {
+		t.Fatalf("CreateUpdateProfile() error = %v", err)
+	}
+	second, err := create("test2", "john.doe@b.com", "")
+	if err != nil {
+		t.Fatalf("CreateUpdateProfile() error = %v", err)
+	}
+	if first.Username != "gopherjohndoe" || second.Username == first.Username || !strings.HasPrefix(second.Username, "gopherjohndoe") {
+		t.Errorf("expected gopherjohndoe and a suffixed one, got %s and %s", first.Username, second.Username)
+	}
+
+	// Test case 2: A taken username, in any case, is refused
+	_, err = create("test3", "test3@email.com", "GopherJohnDoe")
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateUpdateProfile() expected AlreadyExists, got %v", err)
+	}
+
+	// Test case 3: Reserved and malformed usernames are refused
+	for _, username := range []string{"admin", "a b", "x"} {
+		_, err = create("test3", "test3@email.com", username)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateUpdateProfile(%q) expected InvalidArgument, got %v", username, err)
+		}
+	}
+
+	// Test case 4: Updating to another profile's username is refused
+	req := &pb.CreateUpdateProfileRequest{
+		Operation: pb.Operation_UPDATE,
+		Profile:   &pb.Profile{UserId: "test2", Email: "john.doe@b.com", Username: first.Username},
+	}
+	_, err = s.CreateUpdateProfile(context.Background(), req)
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateUpdateProfile() expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestUpdateProfileMask(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	profile := testProfiles()[0]
+	_ = store.CreateProfile(profile)
+	update := func(changes *pb.Profile, paths ...string) (*pb.Profile, error) {
+		changes.UserId, changes.Email = profile.UserId, profile.Email
+		req := &pb.CreateUpdateProfileRequest{
+			Operation:  pb.Operation_UPDATE,
+			Profile:    changes,
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+		}
+		return s.CreateUpdateProfile(context.Background(), req)
+	}
+
+	// Test case 1: Masked fields are cleared, the others are kept
+	updated, err := update(&pb.Profile{Username: "ignored"}, "bio")
+	if err != nil {
+		t.Fatalf("CreateUpdateProfile() error = %v", err)
+	}
+	if updated.Bio != "" || updated.Avatar != profile.Avatar || updated.Username != profile.Username {
+		t.Errorf("expected only the bio cleared, got %v", updated)
+	}
+
+	// Test case 2: Several fields at once
+	updated, _ = update(&pb.Profile{Username: "New_username", Bio: "New bio"}, "username", "bio", "avatar")
+	if updated.Username != "New_username" || updated.Bio != "New bio" || updated.Avatar != "" {
+		t.Errorf("expected username and bio set and avatar cleared, got %v", updated)
+	}
+
+	// Test case 3: Fields that cannot be updated or cleared are refused
+	for _, paths := range [][]string{{"score"}, {"email"}, {"nickname"}, {"username"}, {}} {
+		_, err := update(&pb.Profile{}, paths...)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("CreateUpdateProfile(%v) expected InvalidArgument, got %v", paths, err)
+		}
+	}
+}
+
+func TestCheckUsernameAvailable(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	_ = store.CreateProfile(testProfiles()[0])
+
+	tests := []struct {
+		username  string
+		available bool
+	}{
+		{"Username2", true},
+		{"username1", false},
+		{"admin", false},
+		{"no spaces", false},
+	}
+	for _, test := range tests {
+		res, err := s.CheckUsernameAvailable(context.Background(), &pb.CheckUsernameAvailableRequest{Username: test.username})
+		if err != nil {
+			t.Fatalf("CheckUsernameAvailable() error = %v", err)
+		}
+		if res.Available != test.available || (!res.Available && res.Reason == "") {
+			t.Errorf("CheckUsernameAvailable(%s) expected available %v, got %v", test.username, test.available, res)
+		}
+	}
+
+	// Test case: A taken username comes with a free suggestion
+	res, _ := s.CheckUsernameAvailable(context.Background(), &pb.CheckUsernameAvailableRequest{Username: "Username1"})
+	if res.Suggestion == "" || !strings.HasPrefix(res.Suggestion, "Username1") {
+		t.Errorf("CheckUsernameAvailable expected a suggestion, got %q", res.Suggestion)
+	}
+	if taken, _ := store.UsernameTaken(res.Suggestion); taken {
+		t.Errorf("CheckUsernameAvailable suggested taken username %s", res.Suggestion)
+	}
+}
+
 func TestGetProfile(t *testing.T) {
-	clearProfiles()
-	s := &Server{}
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	s := NewServer(store, nil)
 
 	reqCreate := &pb.CreateUpdateProfileRequest{
 		Operation: pb.Operation_CREATE,
-		Profile:   &profiles[0],
+		Profile:   profiles[0],
 	}
 
 	_, err := s.CreateUpdateProfile(context.Background(), reqCreate)
@@ -126,64 +258,74 @@ func TestGetProfile(t *testing.T) {
 	// check is  profile data is equal
 }
 
-// Mock for testing stream gRPC
-type mockProfileService_GetAllProfilesServer struct {
-	grpc.ServerStream
-	Results []*pb.Profile
-}
-
-func (_m *mockProfileService_GetAllProfilesServer) Send(p *pb.Profile) error {
-	_m.Results = append(_m.Results, p)
-	return nil
-}
-
 func TestGetAllProfiles(t *testing.T) {
-	clearProfiles()
-	s := &Server{}
-
-	mock := &mockProfileService_GetAllProfilesServer{}
-
-	reqCreate := &pb.CreateUpdateProfileRequest{
-		Operation: pb.Operation_CREATE,
-		Profile:   &profiles[0],
-	}
-	_, _ = s.CreateUpdateProfile(context.Background(), reqCreate)
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	s := NewServer(store, nil)
 
-	reqCreate1 := &pb.CreateUpdateProfileRequest{
-		Operation: pb.Operation_CREATE,
-		Profile:   &profiles[1],
+	for _, profile := range profiles {
+		reqCreate := &pb.CreateUpdateProfileRequest{
+			Operation: pb.Operation_CREATE,
+			Profile:   profile,
+		}
+		_, _ = s.CreateUpdateProfile(context.Background(), reqCreate)
 	}
-	_, _ = s.CreateUpdateProfile(context.Background(), reqCreate1)
 
-	reqCreate2 := &pb.CreateUpdateProfileRequest{
-		Operation: pb.Operation_CREATE,
-		Profile:   &profiles[2],
+	// Test case 1: Pages of two follow each other
+	req := &pb.GetAllProfilesRequest{PageSize: 2}
+	var results []*pb.Profile
+	for page := 0; page < 2; page++ {
+		res, err := s.GetAllProfiles(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GetAllProfiles returned error: %v", err)
+		}
+		if res.TotalCount != 3 {
+			t.Errorf("Expected a total of 3 profiles, got %d", res.TotalCount)
+		}
+		results = append(results, res.Profiles...)
+		req.PageToken = res.NextPageToken
 	}
-	_, _ = s.CreateUpdateProfile(context.Background(), reqCreate2)
-
-	err := s.GetAllProfiles(&pb.Empty{}, mock)
-	if err != nil {
-		t.Fatalf("GetAllProfiles returned error: %v", err)
+	if req.PageToken != "" {
+		t.Errorf("Expected no page after the last one, got %q", req.PageToken)
 	}
-
-	// Verify the results in the mock
-	if len(mock.Results) != 3 {
-		t.Errorf("Expected 3 profile, got %d", len(mock.Results))
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 profile, got %d", len(results))
 	}
-	// Check if results isequal
-	for i, result := range mock.Results {
+	for i, result := range results {
 		if result.UserId != profiles[i].UserId {
 			t.Errorf("Profile at index %d: expected user ID %s, got %s", i, profiles[i].UserId, result.UserId)
 		}
 	}
+
+	// Test case 2: Filters narrow the total
+	res, err := s.GetAllProfiles(context.Background(), &pb.GetAllProfilesRequest{UsernamePrefix: "Username2"})
+	if err != nil || res.TotalCount != 1 || res.Profiles[0].UserId != "test2" {
+		t.Errorf("GetAllProfiles expected only test2, got %v %v", res, err)
+	}
+
+	// Test case 3: A token is tied to its sort
+	first, _ := s.GetAllProfiles(context.Background(), &pb.GetAllProfilesRequest{PageSize: 1})
+	for _, req := range []*pb.GetAllProfilesRequest{
+		{PageToken: first.NextPageToken, Sort: pb.ProfileSort_SCORE},
+		{PageToken: "not a token"},
+		{PageSize: -1},
+	} {
+		_, err = s.GetAllProfiles(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetAllProfiles(%v) expected InvalidArgument, got %v", req, err)
+		}
+	}
 }
 
 func TestDeleteProfile(t *testing.T) {
-	clearProfiles()
-	s := &Server{}
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	s := NewServer(store, nil)
 	reqCreate := &pb.CreateUpdateProfileRequest{
 		Operation: pb.Operation_CREATE,
-		Profile:   &profiles[0],
+		Profile:   profiles[0],
 	}
 	_, err := s.CreateUpdateProfile(context.Background(), reqCreate)
 	if err != nil {
@@ -215,11 +357,13 @@ func TestDeleteProfile(t *testing.T) {
 }
 
 func TestUpdateScore(t *testing.T) {
-	clearProfiles()
-	s := &Server{}
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	s := NewServer(store, nil)
 	reqCreate := &pb.CreateUpdateProfileRequest{
 		Operation: pb.Operation_CREATE,
-		Profile:   &profiles[0],
+		Profile:   profiles[0],
 	}
 	_, err := s.CreateUpdateProfile(context.Background(), reqCreate)
 	if err != nil {
@@ -240,3 +384,196 @@ func TestUpdateScore(t *testing.T) {
 		return
 	}
 }
+
+func TestAddScoreService(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	profiles := testProfiles()
+	s := NewServer(store, nil)
+	reqCreate := &pb.CreateUpdateProfileRequest{
+		Operation: pb.Operation_CREATE,
+		Profile:   profiles[0],
+	}
+	_, err := s.CreateUpdateProfile(context.Background(), reqCreate)
+	if err != nil {
+		t.Errorf("CreateUpdateProfile() error = %v", err)
+		return
+	}
+
+	req := &pb.AddScoreRequest{UserId: "test1", Points: 16, IdempotencyKey: "session1"}
+	for i := 0; i < 2; i++ {
+		resp, err := s.AddScore(context.Background(), req)
+		if err != nil {
+			t.Errorf("AddScore() error = %v", err)
+			return
+		}
+		if resp.Score != 16 || resp.Applied != (i == 0) {
+			t.Errorf("AddScore() call %d: got score %d applied %v", i, resp.Score, resp.Applied)
+		}
+	}
+
+	req.IdempotencyKey = ""
+	_, err = s.AddScore(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddScore() expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestExportMyData(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	_ = store.CreateProfile(testProfiles()[0])
+	for _, key := range []string{"session1", "session2"} {
+		if _, _, err := store.AddScore("test1", 5, key); err != nil {
+			t.Fatalf("AddScore() error = %v", err)
+		}
+	}
+
+	// Test case 1: The profile and its score history
+	export, err := s.ExportMyData(context.Background(), &pb.ExportMyDataRequest{UserId: "test1"})
+	if err != nil {
+		t.Fatalf("ExportMyData() error = %v", err)
+	}
+	if export.Profile.UserId != "test1" || export.Profile.Score != 10 {
+		t.Errorf("ExportMyData() got profile %v", export.Profile)
+	}
+	if len(export.ScoreEvents) != 2 || export.ScoreEvents[0].Points != 5 || export.ScoreEvents[0].CreatedAt == nil {
+		t.Errorf("ExportMyData() got score events %v", export.ScoreEvents)
+	}
+
+	// Test case 2: Profile not found
+	_, err = s.ExportMyData(context.Background(), &pb.ExportMyDataRequest{UserId: "not_exist"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("ExportMyData() expected NotFound, got %v", err)
+	}
+
+	// Test case 3: No user
+	_, err = s.ExportMyData(context.Background(), &pb.ExportMyDataRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ExportMyData() expected InvalidArgument, got %v", err)
+	}
+}
+
+// fakeStore is a ProfileStore for testing handlers without a database. The
+// methods a test does not set panic through the nil embedded interface.
+type fakeStore struct {
+	ProfileStore
+	getProfile    func(userId string) (*pb.Profile, error)
+	updateProfile func(p *pb.Profile) error
+	addScore      func(userId string, points int64, key string) (int64, bool, error)
+}
+
+func (f *fakeStore) GetProfileByUserId(userId string) (*pb.Profile, error) {
+	return f.getProfile(userId)
+}
+
+func (f *fakeStore) UpdateProfile(p *pb.Profile) error {
+	return f.updateProfile(p)
+}
+
+func (f *fakeStore) AddScore(userId string, points int64, key string) (int64, bool, error) {
+	return f.addScore(userId, points, key)
+}
+
+func TestHandlersWithFakeStore(t *testing.T) {
+	t.Parallel()
+	store := &fakeStore{
+		getProfile: func(userId string) (*pb.Profile, error) {
+			switch userId {
+			case "test1":
+				return &pb.Profile{UserId: userId, Username: "Username1"}, nil
+			case "broken":
+				return nil, errors.New("connection reset")
+			}
+			return nil, ErrProfileNotFound
+		},
+		addScore: func(userId string, points int64, key string) (int64, bool, error) {
+			if userId != "test1" {
+				return 0, false, ErrProfileNotFound
+			}
+			return points, true, nil
+		},
+	}
+	s := NewServer(store, nil)
+
+	// Test case 1: Store results are passed through
+	profile, err := s.GetProfile(context.Background(), &pb.GetProfileRequest{UserId: "test1"})
+	if err != nil || profile.Username != "Username1" {
+		t.Errorf("GetProfile() got %v, %v", profile, err)
+	}
+
+	// Test case 2: Store errors map to status codes
+	for userId, code := range map[string]codes.Code{"not_exist": codes.NotFound, "broken": codes.Internal} {
+		_, err := s.GetProfile(context.Background(), &pb.GetProfileRequest{UserId: userId})
+		if status.Code(err) != code {
+			t.Errorf("GetProfile(%s) expected %v, got %v", userId, code, err)
+		}
+	}
+	_, err = s.AddScore(context.Background(), &pb.AddScoreRequest{UserId: "not_exist", Points: 1, IdempotencyKey: "session1"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("AddScore() expected NotFound, got %v", err)
+	}
+
+	// Test case 3: An update racing another one is aborted
+	store.updateProfile = func(p *pb.Profile) error { return ErrVersionConflict }
+	req := &pb.CreateUpdateProfileRequest{
+		Operation: pb.Operation_UPDATE,
+		Profile:   &pb.Profile{UserId: "test1", Email: "test1@email.com", Bio: "New bio"},
+	}
+	_, err = s.CreateUpdateProfile(context.Background(), req)
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("CreateUpdateProfile() expected Aborted, got %v", err)
+	}
+}
+
+func TestUpdateProfileExpectedVersion(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	_ = store.CreateProfile(testProfiles()[0])
+	update := func(bio string, version int64) (*pb.Profile, error) {
+		req := &pb.CreateUpdateProfileRequest{
+			Operation:       pb.Operation_UPDATE,
+			Profile:         &pb.Profile{UserId: "test1", Email: "test1@email.com", Bio: bio},
+			ExpectedVersion: version,
+		}
+		return s.CreateUpdateProfile(context.Background(), req)
+	}
+
+	// Test case 1: The expected version is current
+	updated, err := update("first", 1)
+	if err != nil {
+		t.Fatalf("CreateUpdateProfile() error = %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("expected version 2, got %d", updated.Version)
+	}
+
+	// Test case 2: The expected version is stale
+	_, err = update("second", 1)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateUpdateProfile() expected FailedPrecondition, got %v", err)
+	}
+
+	// Test case 3: No expected version skips the check
+	updated, err = update("third", 0)
+	if err != nil || updated.Bio != "third" || updated.Version != 3 {
+		t.Errorf("CreateUpdateProfile() expected bio third at version 3, got %v %v", updated, err)
+	}
+}
+
+func TestServersKeepOwnDatabases(t *testing.T) {
+	t.Parallel()
+	first := NewServer(newTestStore(t), nil)
+	second := NewServer(newTestStore(t), nil)
+
+	req := &pb.CreateUpdateProfileRequest{Operation: pb.Operation_CREATE, Profile: testProfiles()[0]}
+	if _, err := first.CreateUpdateProfile(context.Background(), req); err != nil {
+		t.Fatalf("CreateUpdateProfile() error = %v", err)
+	}
+	_, err := second.GetProfile(context.Background(), &pb.GetProfileRequest{UserId: req.Profile.UserId})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetProfile() on second server expected NotFound, got %v", err)
+	}
+}
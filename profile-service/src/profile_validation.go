@@ -28,6 +28,38 @@ func validateProfile(in *pb.Profile) error {
 	return nil
 }
 
+func validateAddScore(in *pb.AddScoreRequest) error {
+	if in.UserId == "" {
+		return fmt.Errorf("userId is required")
+	}
+	if in.IdempotencyKey == "" {
+		return fmt.Errorf("idempotency_key is required")
+	}
+	if in.Points < 0 {
+		return fmt.Errorf("points must be positive")
+	}
+	return nil
+}
+
+func validateListProfiles(in *pb.GetAllProfilesRequest) error {
+	if in.PageSize < 0 {
+		return fmt.Errorf("page_size must be positive")
+	}
+	if in.MinScore != nil && in.MaxScore != nil && *in.MinScore > *in.MaxScore {
+		return fmt.Errorf("min_score is greater than max_score")
+	}
+	if in.CreatedAfter != nil && in.CreatedBefore != nil && !in.CreatedAfter.AsTime().Before(in.CreatedBefore.AsTime()) {
+		return fmt.Errorf("created_after must be before created_before")
+	}
+	if len(in.UsernamePrefix) > 100 {
+		return fmt.Errorf("username_prefix is longer than 100 characters")
+	}
+	if _, ok := sortColumns[in.Sort]; !ok {
+		return fmt.Errorf("unknown sort: %v", in.Sort)
+	}
+	return nil
+}
+
 // func (ps *ProfileService) validateScore(score int64) error {
 // 	if score < 0 {
 // 		return fmt.Errorf("must be positive int64")
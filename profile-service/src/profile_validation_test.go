@@ -63,3 +63,25 @@ func TestProfileValidation(t *testing.T) {
 // 		t.Errorf("validation error: %v", err)
 // 	}
 // }
+
+func TestListProfilesValidation(t *testing.T) {
+	low, high := int64(10), int64(20)
+
+	// Test case 1: Validate a request with filters
+	err := validateListProfiles(&pb.GetAllProfilesRequest{PageSize: 10, MinScore: &low, MaxScore: &high, Sort: pb.ProfileSort_SCORE})
+	if err != nil {
+		t.Errorf("validation error: %v", err)
+	}
+
+	// Test case 2: Invalidate inconsistent ranges and unknown sorts
+	for _, req := range []*pb.GetAllProfilesRequest{
+		{PageSize: -1},
+		{MinScore: &high, MaxScore: &low},
+		{UsernamePrefix: strings.Repeat("a", 101)},
+		{Sort: pb.ProfileSort(42)},
+	} {
+		if err := validateListProfiles(req); err == nil {
+			t.Errorf("validation error: %v validated", req)
+		}
+	}
+}
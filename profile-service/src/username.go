@@ -0,0 +1,57 @@
+package src
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/Cprime50/user/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// usernameAttempts is how many usernames freeUsername tries before giving
+// up, the first without a suffix.
+const usernameAttempts = 10
+
+// freeUsername returns username when no profile has it, otherwise it with
+// a random suffix of digits that is free.
+func (s *Server) freeUsername(username string) (string, error) {
+	candidate := username
+	for i := 0; i < usernameAttempts; i++ {
+		taken, err := s.store.UsernameTaken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = utils.UsernameWithSuffix(username, fmt.Sprintf("%04d", rand.Intn(10000)))
+	}
+	return "", ErrUsernameUnavailable
+}
+
+// generateUsername returns a free username derived from email.
+func (s *Server) generateUsername(email string) (string, error) {
+	username, err := utils.GenerateUsername(email)
+	if err != nil {
+		return "", err
+	}
+	return s.freeUsername(username)
+}
+
+// checkUsername returns the status error for username when it cannot be
+// chosen: InvalidArgument when it breaks the rules, AlreadyExists when
+// another profile has it.
+func (s *Server) checkUsername(username string) error {
+	if err := utils.ValidateUsername(username); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	taken, err := s.store.UsernameTaken(username)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error checking username: %v", err)
+	}
+	if taken {
+		return status.Errorf(codes.AlreadyExists, "%v: %s", ErrUsernameTaken, username)
+	}
+	return nil
+}
@@ -5,20 +5,91 @@ import (
 	"strings"
 )
 
+const (
+	MinUsernameLength = 3
+	MaxUsernameLength = 30
+)
+
+// reservedUsernames are names, matched case-insensitively, that could pass
+// for the staff or the service itself.
+var reservedUsernames = map[string]bool{
+	"admin":         true,
+	"administrator": true,
+	"moderator":     true,
+	"root":          true,
+	"system":        true,
+	"support":       true,
+	"staff":         true,
+	"shiken":        true,
+	"api":           true,
+	"profile":       true,
+	"quiz":          true,
+	"me":            true,
+	"null":          true,
+	"undefined":     true,
+}
+
+// GenerateUsername derives a username from the local part of an email, its
+// dots, dashes and +tag dropped along with any character a username cannot
+// hold and reserved words.
 func GenerateUsername(email string) (string, error) {
 	parts := strings.Split(email, "@")
 	if len(parts) != 2 {
 		return "", fmt.Errorf("invalid email format")
 	}
 
-	usernamePart := parts[0]
+	usernamePart, _, _ := strings.Cut(parts[0], "+")
 
-	names := strings.Split(usernamePart, ".")
-	if len(names) == 0 {
-		return "", fmt.Errorf("no names found in email")
+	names := strings.FieldsFunc(usernamePart, func(r rune) bool { return r == '.' || r == '-' || r == '_' })
+	kept := names[:0]
+	for _, name := range names {
+		if !reservedUsernames[strings.ToLower(name)] {
+			kept = append(kept, name)
+		}
 	}
 
-	username := "gopher" + strings.Join(names, "")
-
+	username := "gopher" + SanitizeUsername(strings.Join(kept, ""))
+	if len(username) > MaxUsernameLength {
+		username = username[:MaxUsernameLength]
+	}
 	return username, nil
 }
+
+// SanitizeUsername drops the characters a username cannot hold, anything
+// but ASCII letters, digits and underscores.
+func SanitizeUsername(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isUsernameRune(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func isUsernameRune(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_'
+}
+
+// ValidateUsername checks username can be chosen: its length, characters
+// and that it is not reserved.
+func ValidateUsername(username string) error {
+	if len(username) < MinUsernameLength || len(username) > MaxUsernameLength {
+		return fmt.Errorf("username must be %d to %d characters long", MinUsernameLength, MaxUsernameLength)
+	}
+	if SanitizeUsername(username) != username {
+		return fmt.Errorf("username can only contain letters, digits and underscores")
+	}
+	if reservedUsernames[strings.ToLower(username)] {
+		return fmt.Errorf("username %q is reserved", username)
+	}
+	return nil
+}
+
+// UsernameWithSuffix appends suffix to username, cutting username short so
+// the result stays within MaxUsernameLength.
+func UsernameWithSuffix(username, suffix string) string {
+	if len(username)+len(suffix) > MaxUsernameLength {
+		username = username[:MaxUsernameLength-len(suffix)]
+	}
+	return username + suffix
+}
@@ -17,6 +17,11 @@ func TestGenerateUsername(t *testing.T) {
 		{"username@example.com", "gopherusername", nil},
 		{"invalidemailformat", "", fmt.Errorf("invalid email format")},
 		{"singlepart@com", "gophersinglepart", nil},
+		{"john.doe+quiz@example.com", "gopherjohndoe", nil},
+		{"admin.jane@example.com", "gopherjane", nil},
+		{"o'brien@example.com", "gopherobrien", nil},
+		{"averyveryverylongname@example.com", "gopheraveryveryverylongname", nil},
+		{"an.extremely.long.local.part.indeed@example.com", "gopheranextremelylonglocalpart", nil},
 	}
 
 	for _, test := range tests {
@@ -31,3 +36,34 @@ func TestGenerateUsername(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		valid    bool
+	}{
+		{"gopher_42", true},
+		{"ab", false},
+		{"this_username_is_far_too_long_1", false},
+		{"john doe", false},
+		{"jöhn", false},
+		{"Admin", false},
+	}
+
+	for _, test := range tests {
+		err := ValidateUsername(test.username)
+		if (err == nil) != test.valid {
+			t.Errorf("For %s, expected valid %v, but got %v", test.username, test.valid, err)
+		}
+	}
+}
+
+func TestUsernameWithSuffix(t *testing.T) {
+	if got := UsernameWithSuffix("gopher", "0042"); got != "gopher0042" {
+		t.Errorf("expected gopher0042, but got %s", got)
+	}
+	long := "gopheranextremelylonglocalpart"
+	if got := UsernameWithSuffix(long, "0042"); len(got) != MaxUsernameLength || got[len(got)-4:] != "0042" {
+		t.Errorf("expected %d characters ending in 0042, but got %s", MaxUsernameLength, got)
+	}
+}
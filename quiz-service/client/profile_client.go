@@ -0,0 +1,35 @@
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+
+	profilepb "github.com/Cprime50/quiz/profilepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// InitProfileServiceClient connects to profile-service at url. The
+// connection is established lazily, so the quiz service can start before
+// profile-service is up. The caller closes the returned connection.
+func InitProfileServiceClient(env, url, certPath, keyPath string) (*grpc.ClientConn, profilepb.ProfileServiceClient, error) {
+	creds := insecure.NewCredentials()
+	if env == "production" {
+		certificate, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			slog.Error("Error loading TLS certificate", "tls.LoadX509KeyPair", err)
+			return nil, nil, err
+		}
+		creds = credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{certificate},
+		})
+	}
+
+	conn, err := grpc.Dial(url, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, nil, fmt.Errorf("connection to profile gRPC service failed: %v", err)
+	}
+	return conn, profilepb.NewProfileServiceClient(conn), nil
+}
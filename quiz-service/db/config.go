@@ -2,28 +2,80 @@ package db
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
 )
 
-var Db *sql.DB
+// Open opens the database DATABASE_URL points at: postgres:// and
+// postgresql:// URLs open PostgreSQL, anything else is a SQLite file, user.db
+// when url is empty.
+func Open(url string) (*sql.DB, Dialect, error) {
+	if strings.HasPrefix(url, "postgres://") || strings.HasPrefix(url, "postgresql://") {
+		conn, err := sql.Open("postgres", url)
+		if err != nil {
+			return nil, nil, err
+		}
+		conn.SetMaxOpenConns(25)
+		conn.SetMaxIdleConns(5)
+		conn.SetConnMaxIdleTime(5 * time.Minute)
+		return conn, Postgres, nil
+	}
+
+	if url == "" {
+		url = "user.db"
+	}
+	conn, err := sql.Open("sqlite3", sqliteDSN(url))
+	if err != nil {
+		return nil, nil, err
+	}
+	// SQLite has a single writer
+	conn.SetMaxOpenConns(1)
+	return conn, SQLite, nil
+}
 
-func Connect() (*sql.DB, error) {
-	var err error
-	Db, err = sql.Open("sqlite3", "user.db?cache=shared&mode=rwc&_journal_mode=WAL&busy_timeout=10000")
-	Db.SetMaxOpenConns(1)
+// sqliteOptions are the options every SQLite file is opened with.
+const sqliteOptions = "cache=shared&mode=rwc&_journal_mode=WAL&busy_timeout=10000"
+
+// sqliteDSN adds sqliteOptions to the query string of a SQLite url, which
+// may already have one.
+func sqliteDSN(url string) string {
+	dsn := strings.TrimPrefix(url, "sqlite://")
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + sqliteOptions
+	}
+	return dsn + "?" + sqliteOptions
+}
+
+// Connect opens the database url points at, see Open, and checks it is
+// reachable.
+func Connect(url string) (*sql.DB, Dialect, error) {
+	conn, dialect, err := Open(url)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, nil, err
 	}
-	return Db, nil
+	return conn, dialect, nil
 }
 
-func ConnectTest() (*sql.DB, error) {
-	var err error
-	Db, err = sql.Open("sqlite3", "file::memory:?cache=shared&mode=rwc&_journal_mode=WAL&busy_timeout=10000")
-	Db.SetMaxOpenConns(1)
+// testDatabases numbers the databases ConnectTest opens.
+var testDatabases atomic.Int64
+
+// ConnectTest opens a new, empty SQLite database in memory. Every call gets
+// its own, which lives until the returned connection is closed.
+func ConnectTest() (*sql.DB, Dialect, error) {
+	name := fmt.Sprintf("file:test%d?mode=memory&cache=shared&_journal_mode=WAL&busy_timeout=10000", testDatabases.Add(1))
+	conn, err := sql.Open("sqlite3", name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return Db, nil
+	conn.SetMaxOpenConns(1)
+	return conn, SQLite, nil
 }
@@ -0,0 +1,62 @@
+package db
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	// Test case 1: SQLite takes ? placeholders as they are
+	query := "SELECT id FROM quiz WHERE id > ? AND english = ? LIMIT ?"
+	if got := SQLite.Rebind(query); got != query {
+		t.Errorf("expected %q, got %q", query, got)
+	}
+
+	// Test case 2: PostgreSQL placeholders are numbered in order
+	want := "SELECT id FROM quiz WHERE id > $1 AND english = $2 LIMIT $3"
+	if got := Postgres.Rebind(query); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	// Test case 3: question marks in string literals are not placeholders
+	query = "UPDATE quiz SET english = 'Why?' WHERE id = ? AND pronounce <> ''"
+	want = "UPDATE quiz SET english = 'Why?' WHERE id = $1 AND pronounce <> ''"
+	if got := Postgres.Rebind(query); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestMigrationsMatch(t *testing.T) {
+	sqlite, err := Migrations(SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	postgres, err := Migrations(Postgres)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: both dialects have the same numbered migrations
+	if len(sqlite) != len(postgres) {
+		t.Fatalf("expected %d postgres migrations, got %d", len(sqlite), len(postgres))
+	}
+	for i := range sqlite {
+		if sqlite[i].Version != postgres[i].Version || sqlite[i].Name != postgres[i].Name {
+			t.Errorf("migration %d: sqlite has %04d_%s, postgres has %04d_%s",
+				i, sqlite[i].Version, sqlite[i].Name, postgres[i].Version, postgres[i].Name)
+		}
+	}
+}
+
+func TestSqliteDSN(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"user.db", "user.db?" + sqliteOptions},
+		{"sqlite://data/quiz.db", "data/quiz.db?" + sqliteOptions},
+		{"sqlite://file:quiz.db?_foreign_keys=on", "file:quiz.db?_foreign_keys=on&" + sqliteOptions},
+	}
+	for _, tt := range tests {
+		if got := sqliteDSN(tt.url); got != tt.want {
+			t.Errorf("sqliteDSN(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
@@ -2,67 +2,293 @@ package db
 
 import (
 	"database/sql"
+	"embed"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 )
 
-func Migrate(db *sql.DB) error {
-
-	_, err := Db.Exec(`
-        CREATE TABLE IF NOT EXISTS quiz (
-            id INTEGER PRIMARY KEY AUTOINCREMENT,
-			created TIMESTAMP not null default current_timestamp,
-            updated TIMESTAMP not null default current_timestamp,
-            deleted TIMESTAMP,
-            japanese TEXT UNIQUE NOT NULL,
-            pronounce TEXT UNIQUE NOT NULL,
-            english TEXT UNIQUE NOT NULL
+// Migration files are named NNNN_name.up.sql and NNNN_name.down.sql, the
+// number giving the order they are applied in. Each dialect has its own
+// directory of them, numbered alike.
+//
+//go:embed migrations/*/*.sql
+var migrationFiles embed.FS
+
+// Migration is one numbered change to the schema and the SQL undoing it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState is a migration and when it was applied, zero when it is
+// pending.
+type MigrationState struct {
+	Migration
+	AppliedAt time.Time
+}
+
+// Migrations returns the migrations embedded in the binary for dialect in
+// order.
+func Migrations(dialect Dialect) ([]Migration, error) {
+	return loadMigrations(migrationFiles, path.Join("migrations", dialect.Name()))
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		file := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(file, ".sql"), ".")
+		number, name, found := strings.Cut(base, "_")
+		version, err := strconv.Atoi(number)
+		if !ok || !found || err != nil || version < 1 || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %q", file)
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %s: %w", file, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %d is named both %q and %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func ensureMigrationTable(db *sql.DB) error {
+	_, err := db.Exec(`
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version INTEGER PRIMARY KEY,
+            name TEXT NOT NULL,
+            applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
         );
     `)
 	if err != nil {
-		return fmt.Errorf("error creating table quiz: %w", err)
-	}
-
-	// Migrate some data
-	sentences := []struct {
-		Japanese  string
-		Pronounce string
-		English   string
-	}{
-		{"こんにちは、世界！", "Konnichiwa, sekai!", "Hello, world!"},
-		{"おはようございます。", "Ohayou gozaimasu.", "Good morning."},
-		{"こんばんは。", "Konbanwa.", "Good evening."},
-		{"ありがとう。", "Arigatou.", "Thank you."},
-		{"ごめんなさい。", "Gomen nasai.", "I'm sorry."},
-		{"はい、そうです。", "Hai, sou desu.", "Yes, that's right."},
-		{"いいえ、違います。", "Iie, chigaimasu.", "No, that's wrong."},
-		{"お願いします。", "Onegaishimasu.", "Please."},
-		{"どういたしまして。", "Dou itashimashite.", "You're welcome."},
-		{"いってきます。", "Ittekimasu.", "I'm leaving (said when leaving home)."},
-		{"ただいま。", "Tadaima.", "I'm back (said when returning home)."},
-		{"行ってらっしゃい。", "Itte rasshai.", "Take care (said when someone is leaving)."},
-		{"お誕生日おめでとうございます！", "Otanjoubi omedetou gozaimasu!", "Happy birthday!"},
-		{"おめでとうございます！", "Omedetou gozaimasu!", "Congratulations!"},
-		{"おはよう、日本！", "Ohayou, Nihon!", "Good morning, Japan!"},
-		{"こんな天気の日には外で遊びましょう。", "Konna tenki no hi ni wa soto de asobimashou.", "Let's play outside on such a sunny day."},
-		{"もうすぐ春が来ます。", "Mou sugu haru ga kimasu.", "Spring is coming soon."},
-		{"今日はとても寒いですね。", "Kyou wa totemo samui desu ne.", "It's very cold today, isn't it?"},
-		{"明日は晴れるといいですね。", "Ashita wa hareru to ii desu ne.", "I hope it will be sunny tomorrow."},
-		{"この本はとても面白いです。", "Kono hon wa totemo omoshiroi desu.", "This book is very interesting."},
-	}
-
-	stmt, err := Db.Prepare("INSERT INTO quiz (japanese, pronounce, english) VALUES (?, ?, ?)")
+		return fmt.Errorf("error creating table schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// appliedMigrations returns when each applied migration version was applied.
+func appliedMigrations(db *sql.DB) (map[int]time.Time, error) {
+	if err := ensureMigrationTable(db); err != nil {
+		return nil, err
+	}
+	rows, err := db.Query("SELECT version, applied_at FROM schema_migrations")
 	if err != nil {
-		return fmt.Errorf("error preparing db statements: %w", err)
+		return nil, fmt.Errorf("db.Query: %w", err)
 	}
-	defer stmt.Close()
+	defer rows.Close()
 
-	for _, s := range sentences {
-		_, err := stmt.Exec(s.Japanese, s.Pronounce, s.English)
-		if err != nil {
-			return fmt.Errorf("error inserting data into db: %w", err)
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return applied, nil
+}
+
+// runMigration runs the up or down SQL of m and records the change in
+// schema_migrations, all in one transaction.
+func runMigration(db *sql.DB, m Migration, up bool) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if up {
+		if _, err := tx.Exec(m.Up); err != nil {
+			return fmt.Errorf("error applying migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		_, err = tx.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)", m.Version, m.Name, time.Now().UTC())
+	} else {
+		if _, err := tx.Exec(m.Down); err != nil {
+			return fmt.Errorf("error reverting migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		_, err = tx.Exec("DELETE FROM schema_migrations WHERE version = $1", m.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("error recording migration %04d_%s: %w", m.Version, m.Name, err)
+	}
+	return tx.Commit()
+}
+
+// Migrate applies every pending migration and returns how many it applied.
+func Migrate(db *sql.DB, dialect Dialect) (int, error) {
+	migrations, err := Migrations(dialect)
+	if err != nil {
+		return 0, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		if err := runMigration(db, m, true); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// MigrateDown reverts the last n applied migrations, latest first, and
+// returns how many it reverted.
+func MigrateDown(db *sql.DB, dialect Dialect, n int) (int, error) {
+	migrations, err := Migrations(dialect)
+	if err != nil {
+		return 0, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return 0, err
+	}
+	byVersion := make(map[int]Migration, len(migrations))
+	for _, m := range migrations {
+		byVersion[m.Version] = m
+	}
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	count := 0
+	for _, version := range versions {
+		if count == n {
+			break
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			return count, fmt.Errorf("migration %d is applied but unknown to this binary", version)
+		}
+		if err := runMigration(db, m, false); err != nil {
+			return count, err
 		}
+		count++
 	}
+	return count, nil
+}
 
-	fmt.Println("Migration successful.")
+// MigrationStatus returns every known migration with when it was applied.
+func MigrationStatus(db *sql.DB, dialect Dialect) ([]MigrationState, error) {
+	migrations, err := Migrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	states := make([]MigrationState, len(migrations))
+	for i, m := range migrations {
+		states[i] = MigrationState{Migration: m, AppliedAt: applied[m.Version]}
+	}
+	return states, nil
+}
+
+// Command runs the migrate subcommand of the service binary:
+//
+//	migrate up        apply every pending migration
+//	migrate down [N]  revert the last N migrations, 1 by default
+//	migrate status    list migrations and when they were applied
+//	migrate seed      insert the sample quizzes
+func Command(db *sql.DB, dialect Dialect, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up | down [N] | status | seed")
+	}
+
+	switch args[0] {
+	case "up":
+		count, err := Migrate(db, dialect)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Applied %d migrations.\n", count)
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			var err error
+			n, err = strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("migrate down: %q is not a number of migrations", args[1])
+			}
+		}
+		count, err := MigrateDown(db, dialect, n)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Reverted %d migrations.\n", count)
+	case "status":
+		states, err := MigrationStatus(db, dialect)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, state := range states {
+			applied := "pending"
+			if !state.AppliedAt.IsZero() {
+				applied = state.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", state.Version, state.Name, applied)
+		}
+		return w.Flush()
+	case "seed":
+		count, err := Seed(db)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Seeded %d quizzes.\n", count)
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
 	return nil
 }
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS quiz (
+    id BIGSERIAL PRIMARY KEY,
+    created TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted TIMESTAMPTZ,
+    japanese TEXT UNIQUE NOT NULL,
+    pronounce TEXT UNIQUE NOT NULL,
+    english TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS scores (
+    user_id TEXT PRIMARY KEY,
+    username TEXT NOT NULL DEFAULT '',
+    score BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS quiz_session_questions;
+DROP TABLE IF EXISTS quiz_sessions;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS review_states (
+    user_id TEXT NOT NULL,
+    quiz_id BIGINT NOT NULL REFERENCES quiz (id) ON DELETE CASCADE,
+    ease DOUBLE PRECISION NOT NULL DEFAULT 2.5,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    lapses INTEGER NOT NULL DEFAULT 0,
+    due_at TIMESTAMPTZ NOT NULL,
+    reviewed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, quiz_id)
+);
+
+CREATE INDEX IF NOT EXISTS review_states_due_at ON review_states (user_id, due_at);
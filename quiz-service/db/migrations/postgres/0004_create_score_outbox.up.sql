@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS score_outbox (
+    session_id TEXT PRIMARY KEY REFERENCES quiz_sessions (id),
+    user_id TEXT NOT NULL,
+    points BIGINT NOT NULL,
+    attempts BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMPTZ
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS score_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    session_id TEXT UNIQUE,
+    points BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS score_events_created_at ON score_events (created_at);
+
+-- Scores earned before the ledger existed become one event per learner
+INSERT INTO score_events (user_id, points, created_at)
+SELECT user_id, score, updated_at FROM scores
+WHERE score > 0 AND user_id NOT IN (SELECT user_id FROM score_events);
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS decks (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS quiz_decks (
+    deck_id BIGINT NOT NULL REFERENCES decks (id) ON DELETE CASCADE,
+    quiz_id BIGINT NOT NULL REFERENCES quiz (id) ON DELETE CASCADE,
+    PRIMARY KEY (deck_id, quiz_id)
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL
+);
+
+-- Tag names are unique in any case, as COLLATE NOCASE makes them on SQLite
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_lower ON tags (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS quiz_tags (
+    quiz_id BIGINT NOT NULL REFERENCES quiz (id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (quiz_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS quiz_tags_tag_id ON quiz_tags (tag_id);
+
+-- JLPT levels are tags every quiz can be sorted into
+INSERT INTO tags (name) VALUES ('N5'), ('N4'), ('N3'), ('N2'), ('N1') ON CONFLICT DO NOTHING;
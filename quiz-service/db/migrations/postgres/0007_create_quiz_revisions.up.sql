@@ -0,0 +1,17 @@
+-- Before and after columns are NULL when there is no content on that side,
+-- before a quiz is created or after it is deleted
+CREATE TABLE IF NOT EXISTS quiz_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    quiz_id BIGINT NOT NULL REFERENCES quiz (id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    author_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    before_japanese TEXT,
+    before_pronounce TEXT,
+    before_english TEXT,
+    after_japanese TEXT,
+    after_pronounce TEXT,
+    after_english TEXT
+);
+
+CREATE INDEX IF NOT EXISTS quiz_revisions_quiz_id ON quiz_revisions (quiz_id);
@@ -0,0 +1,3 @@
+DELETE FROM quiz_revisions WHERE quiz_id NOT IN (SELECT id FROM quiz);
+ALTER TABLE quiz_revisions ADD CONSTRAINT quiz_revisions_quiz_id_fkey
+    FOREIGN KEY (quiz_id) REFERENCES quiz (id) ON DELETE CASCADE;
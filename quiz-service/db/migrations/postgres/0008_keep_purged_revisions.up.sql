@@ -0,0 +1,3 @@
+-- Revisions outlive their quiz, purging a quiz from the trash keeps its
+-- history
+ALTER TABLE quiz_revisions DROP CONSTRAINT IF EXISTS quiz_revisions_quiz_id_fkey;
@@ -0,0 +1,4 @@
+-- answer is the expected answer when the question was issued, so editing the
+-- quiz during a session does not change how it is graded. It is empty for
+-- questions issued before it was kept.
+ALTER TABLE quiz_session_questions ADD COLUMN answer TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS quiz_sessions (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    seed INTEGER NOT NULL,
+    format INTEGER NOT NULL DEFAULT 0,
+    score INTEGER,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS quiz_session_questions (
+    session_id TEXT NOT NULL REFERENCES quiz_sessions (id) ON DELETE CASCADE,
+    quiz_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    direction INTEGER NOT NULL DEFAULT 0,
+    options TEXT NOT NULL,
+    PRIMARY KEY (session_id, quiz_id)
+);
+
+CREATE INDEX IF NOT EXISTS quiz_sessions_user_id ON quiz_sessions (user_id);
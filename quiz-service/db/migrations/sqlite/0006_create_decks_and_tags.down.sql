@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS quiz_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS quiz_decks;
+DROP TABLE IF EXISTS decks;
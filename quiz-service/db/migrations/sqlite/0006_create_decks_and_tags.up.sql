@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS decks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT UNIQUE NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS quiz_decks (
+    deck_id INTEGER NOT NULL REFERENCES decks (id) ON DELETE CASCADE,
+    quiz_id INTEGER NOT NULL REFERENCES quiz (id) ON DELETE CASCADE,
+    PRIMARY KEY (deck_id, quiz_id)
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT UNIQUE NOT NULL COLLATE NOCASE
+);
+
+CREATE TABLE IF NOT EXISTS quiz_tags (
+    quiz_id INTEGER NOT NULL REFERENCES quiz (id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (quiz_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS quiz_tags_tag_id ON quiz_tags (tag_id);
+
+-- JLPT levels are tags every quiz can be sorted into
+INSERT OR IGNORE INTO tags (name) VALUES ('N5'), ('N4'), ('N3'), ('N2'), ('N1');
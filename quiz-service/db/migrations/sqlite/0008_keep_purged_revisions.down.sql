@@ -0,0 +1,19 @@
+CREATE TABLE quiz_revisions_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    quiz_id INTEGER NOT NULL REFERENCES quiz (id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    author_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    before_japanese TEXT,
+    before_pronounce TEXT,
+    before_english TEXT,
+    after_japanese TEXT,
+    after_pronounce TEXT,
+    after_english TEXT
+);
+
+INSERT INTO quiz_revisions_old SELECT * FROM quiz_revisions WHERE quiz_id IN (SELECT id FROM quiz);
+DROP TABLE quiz_revisions;
+ALTER TABLE quiz_revisions_old RENAME TO quiz_revisions;
+
+CREATE INDEX IF NOT EXISTS quiz_revisions_quiz_id ON quiz_revisions (quiz_id);
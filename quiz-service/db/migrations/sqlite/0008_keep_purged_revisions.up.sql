@@ -0,0 +1,22 @@
+-- Revisions outlive their quiz, purging a quiz from the trash keeps its
+-- history. SQLite cannot drop a foreign key, so the table is rebuilt without
+-- it.
+CREATE TABLE quiz_revisions_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    quiz_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    author_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    before_japanese TEXT,
+    before_pronounce TEXT,
+    before_english TEXT,
+    after_japanese TEXT,
+    after_pronounce TEXT,
+    after_english TEXT
+);
+
+INSERT INTO quiz_revisions_new SELECT * FROM quiz_revisions;
+DROP TABLE quiz_revisions;
+ALTER TABLE quiz_revisions_new RENAME TO quiz_revisions;
+
+CREATE INDEX IF NOT EXISTS quiz_revisions_quiz_id ON quiz_revisions (quiz_id);
@@ -0,0 +1 @@
+ALTER TABLE quiz_session_questions DROP COLUMN answer;
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func openTestDb(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	return count == 1
+}
+
+func TestMigrateUpDown(t *testing.T) {
+	db := openTestDb(t)
+	migrations, err := Migrations(SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test case 1: every migration is applied once
+	applied, err := Migrate(db, SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied != len(migrations) {
+		t.Errorf("expected %d migrations applied, got %d", len(migrations), applied)
+	}
+	applied, err = Migrate(db, SQLite)
+	if err != nil || applied != 0 {
+		t.Errorf("expected nothing applied twice, got %d, %v", applied, err)
+	}
+
+	// Test case 2: seeding is idempotent
+	seeded, err := Seed(db)
+	if err != nil || seeded != len(sentences) {
+		t.Errorf("expected %d quizzes seeded, got %d, %v", len(sentences), seeded, err)
+	}
+	seeded, err = Seed(db)
+	if err != nil || seeded != 0 {
+		t.Errorf("expected nothing seeded twice, got %d, %v", seeded, err)
+	}
+	var revisions int
+	if err := db.QueryRow("SELECT COUNT(*) FROM quiz_revisions WHERE action = 'CREATE' AND author_id = ''").Scan(&revisions); err != nil || revisions != len(sentences) {
+		t.Errorf("expected %d seeded CREATE revisions, got %d, %v", len(sentences), revisions, err)
+	}
+
+	// Test case 3: down reverts the latest migration only
+	reverted, err := MigrateDown(db, SQLite, 1)
+	if err != nil || reverted != 1 {
+		t.Fatalf("expected 1 migration reverted, got %d, %v", reverted, err)
+	}
+	if !tableExists(t, db, "quiz_revisions") {
+		t.Error("expected quiz_revisions rebuilt with its foreign key")
+	}
+	if !tableExists(t, db, "quiz") {
+		t.Error("expected quiz kept")
+	}
+	states, err := MigrationStatus(db, SQLite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := states[len(states)-1]
+	if !last.AppliedAt.IsZero() || states[0].AppliedAt.IsZero() {
+		t.Errorf("expected only the last migration pending, got %+v", states)
+	}
+
+	// Test case 4: down past the first migration stops there
+	reverted, err = MigrateDown(db, SQLite, len(migrations)+5)
+	if err != nil || reverted != len(migrations)-1 {
+		t.Fatalf("expected %d migrations reverted, got %d, %v", len(migrations)-1, reverted, err)
+	}
+	if tableExists(t, db, "quiz") {
+		t.Error("expected quiz dropped")
+	}
+
+	// Test case 5: up applies everything again
+	applied, err = Migrate(db, SQLite)
+	if err != nil || applied != len(migrations) {
+		t.Errorf("expected %d migrations applied, got %d, %v", len(migrations), applied, err)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	db := openTestDb(t)
+
+	// Test case 1: status lists pending migrations
+	var out bytes.Buffer
+	if err := Command(db, SQLite, []string{"status"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "create_quiz ") || !strings.Contains(out.String(), "pending") {
+		t.Errorf("unexpected status output:\n%s", out.String())
+	}
+
+	// Test case 2: up, down and seed report what they did
+	for _, tc := range []struct {
+		args []string
+		want string
+	}{
+		{[]string{"up"}, "Applied 9 migrations."},
+		{[]string{"seed"}, "Seeded 20 quizzes."},
+		{[]string{"down", "2"}, "Reverted 2 migrations."},
+	} {
+		out.Reset()
+		if err := Command(db, SQLite, tc.args, &out); err != nil {
+			t.Fatalf("%v: %v", tc.args, err)
+		}
+		if strings.TrimSpace(out.String()) != tc.want {
+			t.Errorf("%v: expected %q, got %q", tc.args, tc.want, out.String())
+		}
+	}
+
+	// Test case 3: bad arguments are rejected
+	for _, args := range [][]string{nil, {"sideways"}, {"down", "0"}, {"down", "x"}} {
+		if err := Command(db, SQLite, args, &out); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestLoadMigrationsInvalid(t *testing.T) {
+	// Test case 1: a migration without a down file
+	fsys := fstest.MapFS{"m/0001_a.up.sql": {Data: []byte("SELECT 1;")}}
+	if _, err := loadMigrations(fsys, "m"); err == nil {
+		t.Error("expected error for missing down file")
+	}
+
+	// Test case 2: a file that is not numbered
+	fsys = fstest.MapFS{"m/first.up.sql": {Data: []byte("SELECT 1;")}}
+	if _, err := loadMigrations(fsys, "m"); err == nil {
+		t.Error("expected error for unnumbered file")
+	}
+
+	// Test case 3: migrations are ordered by number, not by name
+	fsys = fstest.MapFS{
+		"m/10_b.up.sql":   {Data: []byte("SELECT 1;")},
+		"m/10_b.down.sql": {Data: []byte("SELECT 1;")},
+		"m/2_a.up.sql":    {Data: []byte("SELECT 1;")},
+		"m/2_a.down.sql":  {Data: []byte("SELECT 1;")},
+	}
+	migrations, err := loadMigrations(fsys, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 2 || migrations[1].Version != 10 {
+		t.Errorf("unexpected order: %+v", migrations)
+	}
+}
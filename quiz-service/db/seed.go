@@ -0,0 +1,77 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// sentences are the sample quizzes a new database starts with.
+var sentences = []struct {
+	Japanese  string
+	Pronounce string
+	English   string
+}{
+	{"こんにちは、世界！", "Konnichiwa, sekai!", "Hello, world!"},
+	{"おはようございます。", "Ohayou gozaimasu.", "Good morning."},
+	{"こんばんは。", "Konbanwa.", "Good evening."},
+	{"ありがとう。", "Arigatou.", "Thank you."},
+	{"ごめんなさい。", "Gomen nasai.", "I'm sorry."},
+	{"はい、そうです。", "Hai, sou desu.", "Yes, that's right."},
+	{"いいえ、違います。", "Iie, chigaimasu.", "No, that's wrong."},
+	{"お願いします。", "Onegaishimasu.", "Please."},
+	{"どういたしまして。", "Dou itashimashite.", "You're welcome."},
+	{"いってきます。", "Ittekimasu.", "I'm leaving (said when leaving home)."},
+	{"ただいま。", "Tadaima.", "I'm back (said when returning home)."},
+	{"行ってらっしゃい。", "Itte rasshai.", "Take care (said when someone is leaving)."},
+	{"お誕生日おめでとうございます！", "Otanjoubi omedetou gozaimasu!", "Happy birthday!"},
+	{"おめでとうございます！", "Omedetou gozaimasu!", "Congratulations!"},
+	{"おはよう、日本！", "Ohayou, Nihon!", "Good morning, Japan!"},
+	{"こんな天気の日には外で遊びましょう。", "Konna tenki no hi ni wa soto de asobimashou.", "Let's play outside on such a sunny day."},
+	{"もうすぐ春が来ます。", "Mou sugu haru ga kimasu.", "Spring is coming soon."},
+	{"今日はとても寒いですね。", "Kyou wa totemo samui desu ne.", "It's very cold today, isn't it?"},
+	{"明日は晴れるといいですね。", "Ashita wa hareru to ii desu ne.", "I hope it will be sunny tomorrow."},
+	{"この本はとても面白いです。", "Kono hon wa totemo omoshiroi desu.", "This book is very interesting."},
+}
+
+// Seed inserts the sample quizzes that are not in the database yet and
+// returns how many it inserted, so running it again is harmless. Each one
+// gets a CREATE revision without an author.
+func Seed(db *sql.DB) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("INSERT INTO quiz (japanese, pronounce, english) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING RETURNING id")
+	if err != nil {
+		return 0, fmt.Errorf("error preparing db statements: %w", err)
+	}
+	defer stmt.Close()
+	revision, err := tx.Prepare(`
+        INSERT INTO quiz_revisions (quiz_id, action, author_id, created_at, after_japanese, after_pronounce, after_english)
+        VALUES ($1, 'CREATE', '', $2, $3, $4, $5)`)
+	if err != nil {
+		return 0, fmt.Errorf("error preparing db statements: %w", err)
+	}
+	defer revision.Close()
+
+	now := time.Now().UTC()
+	count := 0
+	for _, s := range sentences {
+		var id int64
+		err := stmt.QueryRow(s.Japanese, s.Pronounce, s.English).Scan(&id)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error inserting data into db: %w", err)
+		}
+		if _, err := revision.Exec(id, now, s.Japanese, s.Pronounce, s.English); err != nil {
+			return 0, fmt.Errorf("error recording revision: %w", err)
+		}
+		count++
+	}
+	return count, tx.Commit()
+}
@@ -0,0 +1,57 @@
+package grading
+
+// Kind selects how an answer is compared with the expected one.
+type Kind int
+
+const (
+	// KindText compares normalized text, for translations.
+	KindText Kind = iota
+	// KindReading compares Japanese readings written in kana or romaji.
+	KindReading
+)
+
+// Grade returns the credit of answer against expected, from 0 for nothing
+// in common to 1 for the same answer once both are normalized for kind.
+func Grade(kind Kind, expected, answer string) float64 {
+	fold := Normalize
+	if kind == KindReading {
+		fold = NormalizeReading
+	}
+	return Similarity(fold(expected), fold(answer))
+}
+
+// Similarity is 1 minus the edit distance between a and b relative to the
+// longer of the two, counted in runes.
+func Similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(ra, rb))/float64(longest)
+}
+
+// Levenshtein returns the number of single rune insertions, deletions and
+// substitutions that turn a into b.
+func Levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
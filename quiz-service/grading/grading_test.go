@@ -0,0 +1,100 @@
+package grading
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"Konnichiwa, sekai!": "konnichiwa sekai",
+		"Ｈｅｌｌｏ,　 World！":     "hello world",
+		"  I'm   sorry. ":    "im sorry",
+		"sou-desu":           "sou desu",
+		"":                   "",
+	}
+	for in, want := range cases {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRomanize(t *testing.T) {
+	cases := map[string]string{
+		"こんにちは":     "konnichiha",
+		"おはようございます": "ohayougozaimasu",
+		"きょう":       "kyou",
+		"しゃしん":      "shashin",
+		"ちょっと":      "chotto",
+		"まっちゃ":      "matcha",
+		"ラーメン":      "raamen",
+		"ファイル":      "fairu",
+		"ｶﾀｶﾅ":      "ｶﾀｶﾅ",
+		"日本":        "日本",
+	}
+	for in, want := range cases {
+		if got := Romanize(in); got != want {
+			t.Errorf("Romanize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGradeReading(t *testing.T) {
+	// Test case 1: Equivalent spellings get full credit
+	same := [][2]string{
+		{"Konnichiwa, sekai!", "Konnichiwa sekai"},
+		{"Ohayou gozaimasu.", "おはようございます"},
+		{"Arigatou.", "arigatō"},
+		{"Arigatou.", "arigato"},
+		{"Otanjoubi omedetou gozaimasu!", "otanjoobi omedetoo gozaimasu"},
+		{"Gomen nasai.", "gomennasai"},
+		{"Kyou wa totemo samui desu ne.", "ＫＹＯＵ ＷＡ ＴＯＴＥＭＯ ＳＡＭＵＩ ＤＥＳＵ ＮＥ"},
+		{"Raamen", "ラーメン"},
+		{"Raamen", "ﾗｰﾒﾝ"},
+	}
+	for _, c := range same {
+		if got := Grade(KindReading, c[0], c[1]); got != 1 {
+			t.Errorf("Grade(%q, %q) = %v, want 1", c[0], c[1], got)
+		}
+	}
+
+	// Test case 2: A typo gets partial credit
+	got := Grade(KindReading, "Konbanwa.", "konbamwa")
+	if got <= 0.8 || got >= 1 {
+		t.Errorf("Expected partial credit for a typo, got %v", got)
+	}
+
+	// Test case 3: Something else gets little credit
+	if got := Grade(KindReading, "Konbanwa.", "sayounara"); got > 0.5 {
+		t.Errorf("Expected little credit for a wrong answer, got %v", got)
+	}
+}
+
+func TestGradeText(t *testing.T) {
+	if got := Grade(KindText, "Hello, world!", "hello world"); got != 1 {
+		t.Errorf("Expected full credit, got %v", got)
+	}
+	// Readings are not folded for translations
+	if got := Grade(KindText, "Good morning.", "good mooorning"); got == 1 {
+		t.Errorf("Expected partial credit, got %v", got)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"abc", "", 0},
+		{"kitten", "sitting", 1 - 3.0/7},
+		{"日本", "日本", 1},
+		{"日本", "日木", 0.5},
+	}
+	for _, c := range cases {
+		if got := Similarity(c.a, c.b); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("Similarity(%q, %q) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package grading compares typed answers with the expected ones, forgiving
+// the differences that do not make an answer wrong: case, punctuation,
+// full-width characters, long vowel spellings and kana versus romaji.
+package grading
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// Normalize folds case and character width, drops punctuation and symbols
+// and collapses runs of spaces, so "Ｈｅｌｌｏ,  World!" becomes "hello world".
+func Normalize(s string) string {
+	s = width.Fold.String(s)
+
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			space = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r):
+			// Punctuation separates words as much as it joins them, "sou-desu"
+			// and "sou desu" are the same answer
+			if r == '-' || r == '/' {
+				space = true
+			}
+		default:
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// longVowels spells vowels with a macron or circumflex as two letters, the
+// way they are typed without an IME.
+var longVowels = strings.NewReplacer(
+	"ā", "aa", "ī", "ii", "ū", "uu", "ē", "ee", "ō", "ou",
+	"â", "aa", "î", "ii", "û", "uu", "ê", "ee", "ô", "ou",
+)
+
+// vowelPairs folds the spellings of long vowels found in romaji, "ou", "oo"
+// and "ō" all read as a long o.
+var vowelPairs = strings.NewReplacer(
+	"aa", "a", "ii", "i", "uu", "u", "ee", "e", "ei", "e", "oo", "o", "ou", "o",
+)
+
+// NormalizeReading returns the form used to compare Japanese readings. Kana
+// is romanized, long vowels are folded and spaces are dropped since romaji
+// word breaks are not consistent ("gomen nasai", "gomennasai").
+func NormalizeReading(s string) string {
+	s = Normalize(Romanize(width.Fold.String(s)))
+	s = longVowels.Replace(s)
+	s = strings.ReplaceAll(s, " ", "")
+	// Twice, so a vowel left over by the first pass joins the next one
+	return vowelPairs.Replace(vowelPairs.Replace(s))
+}
@@ -0,0 +1,127 @@
+package grading
+
+import "strings"
+
+// hiragana maps every kana to its Hepburn romanization. Katakana is shifted
+// to hiragana before the lookup.
+var hiragana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ゔ': "vu",
+}
+
+// smallKana are written after another kana and change its vowel, as in
+// きゃ (kya) or ファ (fa).
+var smallKana = map[rune]string{
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+}
+
+const (
+	sokuon    = 'っ'
+	longMark  = 'ー'
+	katakanaA = 'ァ'
+	katakanaZ = 'ヶ'
+	// kanaShift is the distance between a katakana and its hiragana.
+	kanaShift = 'ァ' - 'ぁ'
+)
+
+// Romanize converts the hiragana and katakana in s to Hepburn romaji and
+// leaves everything else as it is. っ doubles the next consonant and ー
+// repeats the previous vowel.
+func Romanize(s string) string {
+	var b strings.Builder
+	double := false
+	for _, r := range s {
+		if r >= katakanaA && r <= katakanaZ {
+			r -= kanaShift
+		}
+
+		if r == sokuon {
+			double = true
+			continue
+		}
+		if r == longMark {
+			if v := lastVowel(b.String()); v != 0 {
+				b.WriteByte(v)
+			}
+			continue
+		}
+		if small, ok := smallKana[r]; ok {
+			writeSmallKana(&b, small)
+			continue
+		}
+
+		romaji, ok := hiragana[r]
+		if !ok {
+			double = false
+			b.WriteRune(r)
+			continue
+		}
+		if double {
+			// っち is spelled "tchi"
+			if strings.HasPrefix(romaji, "ch") {
+				b.WriteByte('t')
+			} else if romaji[0] != 'n' && !isVowel(romaji[0]) {
+				b.WriteByte(romaji[0])
+			}
+			double = false
+		}
+		b.WriteString(romaji)
+	}
+	return b.String()
+}
+
+// writeSmallKana merges a small kana into the syllable written before it:
+// き+ゃ is "kya", し+ゃ is "sha" and ふ+ぁ is "fa".
+func writeSmallKana(b *strings.Builder, small string) {
+	out := b.String()
+	if out == "" || !isVowel(out[len(out)-1]) {
+		b.WriteString(small)
+		return
+	}
+	last, base := out[len(out)-1], out[:len(out)-1]
+	switch {
+	case small[0] != 'y':
+		// ふぁ, ティ: the small vowel replaces the vowel of the syllable
+	case last != 'i':
+		// ゃ, ゅ and ょ only combine with an i syllable
+		b.WriteString(small)
+		return
+	case strings.HasSuffix(base, "sh"), strings.HasSuffix(base, "ch"), strings.HasSuffix(base, "j"):
+		small = small[1:]
+	}
+	b.Reset()
+	b.WriteString(base)
+	b.WriteString(small)
+}
+
+func lastVowel(s string) byte {
+	for i := len(s) - 1; i >= 0; i-- {
+		if isVowel(s[i]) {
+			return s[i]
+		}
+	}
+	return 0
+}
+
+func isVowel(c byte) bool {
+	switch c {
+	case 'a', 'i', 'u', 'e', 'o':
+		return true
+	}
+	return false
+}
@@ -1,47 +1,138 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"log"
 	"log/slog"
 	"net"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/Cprime50/quiz/client"
 	"github.com/Cprime50/quiz/db"
 	pb "github.com/Cprime50/quiz/quizpb"
 	"github.com/Cprime50/quiz/src"
 	"github.com/Cprime50/quiz/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
 var (
-	_         = utils.LoadEnv()
-	ENV       = utils.MustHaveEnv("ENV")
-	GRPC_PORT = utils.MustHaveEnv("GRPC_PORT")
-	CERT_PATH = utils.MustHaveEnv("CERT_PATH")
-	KEY_PATH  = utils.MustHaveEnv("KEY_PATH")
+	_ = utils.LoadEnv()
+
+	// ENV, GRPC_PORT, CERT_PATH, KEY_PATH and PROFILE_SVC_URL are only
+	// needed to serve, see loadServeEnv.
+	ENV, GRPC_PORT, CERT_PATH, KEY_PATH, PROFILE_SVC_URL string
+
+	// TRASH_RETENTION_DAYS is how many days deleted quizzes can be restored,
+	// 30 when it is not set.
+	TRASH_RETENTION_DAYS = os.Getenv("TRASH_RETENTION_DAYS")
+
+	// DATABASE_URL selects the database: a postgres:// URL for PostgreSQL,
+	// otherwise a SQLite file, user.db when it is not set.
+	DATABASE_URL = os.Getenv("DATABASE_URL")
+)
+
+const (
+	// scoreRetryInterval is how often points profile-service did not
+	// confirm are sent again.
+	scoreRetryInterval = time.Minute
+	// trashPurgeInterval is how often quizzes past the trash retention are
+	// deleted for good.
+	trashPurgeInterval = time.Hour
 )
 
 func main() {
-	server := &src.Server{}
+	// "migrate up|down [N]|status|seed" manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		Db, dialect, err := db.Connect(DATABASE_URL)
+		if err != nil {
+			log.Fatal("Error connecting to Db", err)
+		}
+		defer Db.Close()
+		if err := db.Command(Db, dialect, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	loadServeEnv()
+
+	// Connect profile service
+	conn, profiles, err := client.InitProfileServiceClient(ENV, PROFILE_SVC_URL, CERT_PATH, KEY_PATH)
+	if err != nil {
+		log.Fatal("Error connecting to profile service", err)
+	}
+	defer conn.Close()
+
+	var trashRetention time.Duration
+	if TRASH_RETENTION_DAYS != "" {
+		days, err := strconv.Atoi(TRASH_RETENTION_DAYS)
+		if err != nil || days < 1 {
+			log.Fatal("TRASH_RETENTION_DAYS must be a number of days: ", TRASH_RETENTION_DAYS)
+		}
+		trashRetention = time.Duration(days) * 24 * time.Hour
+	}
 
 	//Connect db
-	Db, err := db.Connect()
+	Db, dialect, err := db.Connect(DATABASE_URL)
 	if err != nil {
 		slog.Error("Error opening database", "db.Connect", err)
 		log.Fatal("Error connecting to Db", err)
 	}
-	log.Println("Database connected successfully")
+	log.Printf("Database connected successfully: %s", dialect.Name())
 
 	// migrations
 	log.Printf("Migrations Started")
-	err = db.Migrate(Db)
+	states, err := db.MigrationStatus(Db, dialect)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer db.Db.Close()
+	applied, err := db.Migrate(Db, dialect)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Migrations applied: %d", applied)
+	// A new database starts with the sample quizzes, later ones only come
+	// from "migrate seed" so purged or edited samples stay that way
+	if len(states) > 0 && states[0].AppliedAt.IsZero() {
+		seeded, err := db.Seed(Db)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Sample quizzes seeded: %d", seeded)
+	}
+	defer Db.Close()
+
+	server := src.NewServer(src.NewQuizStore(Db, dialect), slog.Default())
+	server.Profiles = profiles
+	server.TrashRetention = trashRetention
+
+	// Resend score awards profile-service missed
+	go func() {
+		ticker := time.NewTicker(scoreRetryInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := server.RetryPendingScores(context.Background()); err != nil {
+				slog.Error("Error retrying pending scores", "RetryPendingScores", err)
+			}
+		}
+	}()
+
+	// Delete quizzes that have been in the trash for too long
+	go func() {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := server.PurgeExpiredTrash(); err != nil {
+				slog.Error("Error purging trash", "PurgeExpiredTrash", err)
+			}
+		}
+	}()
 
 	// Run the gRPC server
 	lis, err := net.Listen("tcp", fmt.Sprintf("%v", GRPC_PORT))
@@ -50,6 +141,12 @@ func main() {
 		panic(err)
 	}
 
+	// Let api-service keep idle connections alive with pings
+	keepalivePolicy := grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             20 * time.Second,
+		PermitWithoutStream: true,
+	})
+
 	var s *grpc.Server
 	if ENV == "production" {
 		certificate, err := tls.LoadX509KeyPair(CERT_PATH, KEY_PATH)
@@ -57,10 +154,10 @@ func main() {
 			slog.Error("Error loading TLS certificate", "tls.LoadX509KeyPair", err)
 			panic(err)
 		}
-		s = grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&certificate)))
+		s = grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&certificate)), keepalivePolicy)
 	} else {
 		//grpcLogger := grpc.UnaryInterceptor(utils.GrpcLogger)
-		s = grpc.NewServer()
+		s = grpc.NewServer(keepalivePolicy)
 	}
 
 	reflection.Register(s)
@@ -75,3 +172,13 @@ func main() {
 	}
 
 }
+
+// loadServeEnv reads the environment the gRPC server needs, so the migrate
+// command runs without it.
+func loadServeEnv() {
+	ENV = utils.MustHaveEnv("ENV")
+	GRPC_PORT = utils.MustHaveEnv("GRPC_PORT")
+	CERT_PATH = utils.MustHaveEnv("CERT_PATH")
+	KEY_PATH = utils.MustHaveEnv("KEY_PATH")
+	PROFILE_SVC_URL = utils.MustHaveEnv("PROFILE_SVC_URL")
+}
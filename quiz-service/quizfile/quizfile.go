@@ -0,0 +1,94 @@
+// Package quizfile reads and writes quizzes in the files content is shared
+// in: CSV, JSON lines and the tab separated notes Anki imports and exports.
+package quizfile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is the layout of a quiz file.
+type Format int
+
+const (
+	// CSV has one quiz per row, with an optional
+	// japanese,pronounce,english,tags header row.
+	CSV Format = iota
+	// JSONLines has one JSON object per line.
+	JSONLines
+	// AnkiTSV is the "Notes in Plain Text" layout of Anki: tab separated
+	// fields, with # header lines such as "#tags column:4".
+	AnkiTSV
+)
+
+// Record is one quiz in a file. In CSV and Anki files the tags are one field
+// separated by spaces, the way Anki writes them.
+type Record struct {
+	Japanese  string   `json:"japanese"`
+	Pronounce string   `json:"pronounce"`
+	English   string   `json:"english"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// RowError is a row that could not be read. The rows after it can still be
+// read.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// columns are the fields of a row in CSV and Anki files when nothing says
+// otherwise.
+var columns = []string{"japanese", "pronounce", "english", "tags"}
+
+// fromFields builds a record from the fields of a row, index maps each
+// column name to its field.
+func fromFields(fields []string, index map[string]int) (Record, error) {
+	field := func(name string) string {
+		i, ok := index[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+	for _, name := range columns[:3] {
+		if i, ok := index[name]; !ok || i >= len(fields) {
+			return Record{}, fmt.Errorf("missing %s field", name)
+		}
+	}
+	rec := Record{
+		Japanese:  field("japanese"),
+		Pronounce: field("pronounce"),
+		English:   field("english"),
+	}
+	if tags := strings.Fields(field("tags")); len(tags) > 0 {
+		rec.Tags = tags
+	}
+	return rec, nil
+}
+
+// toFields returns the fields of rec in the order of columns. Spaces in tags
+// become underscores, as Anki does, so a tag stays one tag.
+func toFields(rec Record) []string {
+	tags := make([]string, len(rec.Tags))
+	for i, tag := range rec.Tags {
+		tags[i] = strings.Join(strings.Fields(tag), "_")
+	}
+	return []string{rec.Japanese, rec.Pronounce, rec.English, strings.Join(tags, " ")}
+}
+
+func defaultIndex() map[string]int {
+	index := make(map[string]int, len(columns))
+	for i, name := range columns {
+		index[name] = i
+	}
+	return index
+}
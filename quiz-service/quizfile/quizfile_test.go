@@ -0,0 +1,111 @@
+package quizfile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readAll(t *testing.T, in string, format Format) ([]Record, []int) {
+	t.Helper()
+	r, err := NewReader(strings.NewReader(in), format)
+	if err != nil {
+		t.Fatalf("NewReader error: %v", err)
+	}
+	var records []Record
+	var errLines []int
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return records, errLines
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			errLines = append(errLines, rowErr.Line)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Read error: %v", err)
+		}
+		records = append(records, rec)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	records := []Record{
+		{Japanese: "猫です。", Pronounce: "Neko desu.", English: "It's a cat, \"neko\".", Tags: []string{"N5", "animals"}},
+		{Japanese: "犬です。", Pronounce: "Inu desu.", English: "It's a dog.\tWoof."},
+	}
+	for _, format := range []Format{CSV, JSONLines, AnkiTSV} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, format)
+		if err != nil {
+			t.Fatalf("NewWriter error: %v", err)
+		}
+		for _, rec := range records {
+			if err := w.Write(rec); err != nil {
+				t.Fatalf("Write error: %v", err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush error: %v", err)
+		}
+
+		got, errLines := readAll(t, buf.String(), format)
+		if len(errLines) != 0 {
+			t.Errorf("format %d: unexpected row errors on lines %v", format, errLines)
+		}
+		if !reflect.DeepEqual(got, records) {
+			t.Errorf("format %d: got %+v, want %+v", format, got, records)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	// Test case 1: Columns follow the header, whatever their order
+	in := "\ufeffEnglish,Japanese,Pronounce\nIt's a cat.,猫です。,Neko desu.\n"
+	got, _ := readAll(t, in, CSV)
+	want := []Record{{Japanese: "猫です。", Pronounce: "Neko desu.", English: "It's a cat."}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// Test case 2: Without a header, a short row is reported and skipped
+	in = "猫です。,Neko desu.,It's a cat.\n犬です。,Inu desu.\n鳥です。,Tori desu.,It's a bird.,N5\n"
+	got, errLines := readAll(t, in, CSV)
+	if len(got) != 2 || !reflect.DeepEqual(errLines, []int{2}) {
+		t.Errorf("expected 2 records and an error on line 2, got %+v and %v", got, errLines)
+	}
+}
+
+func TestReadJSONLines(t *testing.T) {
+	in := `{"japanese":"猫です。","pronounce":"Neko desu.","english":"It's a cat."}
+
+{"japanese":
+{"japanese":"犬です。","pronounce":"Inu desu.","english":"It's a dog.","tags":["N5"]}
+`
+	got, errLines := readAll(t, in, JSONLines)
+	if len(got) != 2 || got[1].Tags[0] != "N5" {
+		t.Errorf("expected 2 records, got %+v", got)
+	}
+	if !reflect.DeepEqual(errLines, []int{3}) {
+		t.Errorf("expected an error on line 3, got %v", errLines)
+	}
+}
+
+func TestReadAnki(t *testing.T) {
+	in := "#separator:semicolon\n#html:true\n#tags column:5\n" +
+		"猫です。;Neko desu.;It's a <b>cat</b>.;Animals;N5 animals\n" +
+		"犬です。;Inu desu.\n"
+	got, errLines := readAll(t, in, AnkiTSV)
+	want := []Record{{Japanese: "猫です。", Pronounce: "Neko desu.", English: "It's a cat.", Tags: []string{"N5", "animals"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(errLines, []int{5}) {
+		t.Errorf("expected an error on line 5, got %v", errLines)
+	}
+}
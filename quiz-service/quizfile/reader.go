@@ -0,0 +1,231 @@
+package quizfile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxLineSize is the longest JSON line read.
+const maxLineSize = 1 << 20
+
+// Reader reads the records of a quiz file one at a time.
+type Reader struct {
+	next func() (Record, error)
+	line int
+}
+
+// NewReader returns a reader of the records in r. Read returns io.EOF once
+// every record is read, and a *RowError for a row it skipped.
+func NewReader(r io.Reader, format Format) (*Reader, error) {
+	reader := &Reader{}
+	switch format {
+	case CSV:
+		reader.next = reader.csv(newCSVReader(r, ','), nil, 0, false)
+	case JSONLines:
+		reader.next = reader.jsonLines(r)
+	case AnkiTSV:
+		br := bufio.NewReader(r)
+		header, lines, err := readAnkiHeader(br)
+		if err != nil {
+			return nil, err
+		}
+		reader.next = reader.csv(newCSVReader(br, header.separator), header.index(), lines, header.html)
+	default:
+		return nil, fmt.Errorf("unknown format: %d", format)
+	}
+	return reader, nil
+}
+
+// Read returns the next record.
+func (r *Reader) Read() (Record, error) {
+	return r.next()
+}
+
+// Line is the line the last record started on.
+func (r *Reader) Line() int {
+	return r.line
+}
+
+func newCSVReader(r io.Reader, comma rune) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	return cr
+}
+
+// csv reads rows of fields. Without an index the first row may be a header
+// naming the columns, lines is the number of lines read before cr started.
+func (r *Reader) csv(cr *csv.Reader, index map[string]int, lines int, stripHTML bool) func() (Record, error) {
+	first := true
+	return func() (Record, error) {
+		for {
+			fields, err := cr.Read()
+			if err != nil {
+				var parseErr *csv.ParseError
+				if errors.As(err, &parseErr) {
+					r.line = lines + parseErr.StartLine
+					return Record{}, &RowError{Line: r.line, Err: parseErr.Err}
+				}
+				return Record{}, err
+			}
+			line, _ := cr.FieldPos(0)
+			r.line = lines + line
+
+			if first {
+				first = false
+				// Spreadsheets save CSV with a byte order mark
+				fields[0] = strings.TrimPrefix(fields[0], "\ufeff")
+				if index == nil {
+					if header, ok := headerIndex(fields); ok {
+						index = header
+						continue
+					}
+					index = defaultIndex()
+				}
+			}
+			if stripHTML {
+				for i := range fields {
+					fields[i] = htmlText(fields[i])
+				}
+			}
+
+			rec, err := fromFields(fields, index)
+			if err != nil {
+				return Record{}, &RowError{Line: r.line, Err: err}
+			}
+			return rec, nil
+		}
+	}
+}
+
+// headerIndex maps the column names of a header row to their field, it is
+// false when fields are not column names.
+func headerIndex(fields []string) (map[string]int, bool) {
+	index := make(map[string]int, len(fields))
+	for i, field := range fields {
+		name := strings.ToLower(strings.TrimSpace(field))
+		known := false
+		for _, column := range columns {
+			known = known || name == column
+		}
+		if !known {
+			return nil, false
+		}
+		index[name] = i
+	}
+	return index, true
+}
+
+func (r *Reader) jsonLines(in io.Reader) func() (Record, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return func() (Record, error) {
+		for scanner.Scan() {
+			r.line++
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			var rec Record
+			if err := json.Unmarshal([]byte(line), &rec); err != nil {
+				return Record{}, &RowError{Line: r.line, Err: err}
+			}
+			rec.Japanese = strings.TrimSpace(rec.Japanese)
+			rec.Pronounce = strings.TrimSpace(rec.Pronounce)
+			rec.English = strings.TrimSpace(rec.English)
+			return rec, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return Record{}, fmt.Errorf("line %d: %w", r.line+1, err)
+		}
+		return Record{}, io.EOF
+	}
+}
+
+// ankiHeader holds the # lines at the top of an Anki export.
+type ankiHeader struct {
+	separator rune
+	html      bool
+	// tagsColumn counts from 1, 0 means the column after english
+	tagsColumn int
+}
+
+func (h ankiHeader) index() map[string]int {
+	index := defaultIndex()
+	if h.tagsColumn > 0 {
+		index["tags"] = h.tagsColumn - 1
+	}
+	return index
+}
+
+// ankiSeparators are the separator names Anki writes in its header.
+var ankiSeparators = map[string]rune{
+	"tab":       '\t',
+	"comma":     ',',
+	"semicolon": ';',
+	"space":     ' ',
+	"pipe":      '|',
+	"colon":     ':',
+}
+
+// readAnkiHeader reads the header lines of an Anki export and returns them
+// with the number of lines read.
+func readAnkiHeader(br *bufio.Reader) (ankiHeader, int, error) {
+	header := ankiHeader{separator: '\t'}
+	lines := 0
+	for {
+		next, err := br.Peek(1)
+		if err != nil || next[0] != '#' {
+			if err == io.EOF {
+				err = nil
+			}
+			return header, lines, err
+		}
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return header, lines, err
+		}
+		lines++
+
+		key, value, _ := strings.Cut(strings.TrimSpace(line[1:]), ":")
+		switch strings.ToLower(key) {
+		case "separator":
+			if sep, ok := ankiSeparators[strings.ToLower(value)]; ok {
+				header.separator = sep
+			} else if runes := []rune(value); len(runes) == 1 {
+				header.separator = runes[0]
+			} else {
+				return header, lines, &RowError{Line: lines, Err: fmt.Errorf("unknown separator %q", value)}
+			}
+		case "html":
+			header.html = value == "true"
+		case "tags column":
+			column, err := strconv.Atoi(value)
+			if err != nil || column < 1 {
+				return header, lines, &RowError{Line: lines, Err: fmt.Errorf("invalid tags column %q", value)}
+			}
+			header.tagsColumn = column
+		}
+	}
+}
+
+var (
+	lineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</div>`)
+	htmlTags   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// htmlText returns the text of an Anki field saved as HTML.
+func htmlText(field string) string {
+	field = lineBreaks.ReplaceAllString(field, " ")
+	field = htmlTags.ReplaceAllString(field, "")
+	return html.UnescapeString(field)
+}
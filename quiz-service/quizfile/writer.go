@@ -0,0 +1,75 @@
+package quizfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ankiFileHeader tells Anki how to read the file written for it.
+const ankiFileHeader = "#separator:tab\n#html:false\n#tags column:4\n"
+
+// Writer writes records in the layout NewReader reads back.
+type Writer struct {
+	out     io.Writer
+	format  Format
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+}
+
+func NewWriter(w io.Writer, format Format) (*Writer, error) {
+	writer := &Writer{out: w, format: format}
+	switch format {
+	case CSV:
+		writer.csv = csv.NewWriter(w)
+	case AnkiTSV:
+		writer.csv = csv.NewWriter(w)
+		writer.csv.Comma = '\t'
+	case JSONLines:
+		writer.json = json.NewEncoder(w)
+		writer.json.SetEscapeHTML(false)
+	default:
+		return nil, fmt.Errorf("unknown format: %d", format)
+	}
+	return writer, nil
+}
+
+// Write writes rec, after the header of the file when it is the first one.
+func (w *Writer) Write(rec Record) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.json != nil {
+		return w.json.Encode(rec)
+	}
+	return w.csv.Write(toFields(rec))
+}
+
+// Flush writes any buffered data, and the header of an empty file.
+func (w *Writer) Flush() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+func (w *Writer) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	switch w.format {
+	case CSV:
+		return w.csv.Write(columns)
+	case AnkiTSV:
+		_, err := io.WriteString(w.out, ankiFileHeader)
+		return err
+	}
+	return nil
+}
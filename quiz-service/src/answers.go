@@ -0,0 +1,92 @@
+package src
+
+import (
+	"math"
+
+	"github.com/Cprime50/quiz/grading"
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+// typedAcceptCredit is the credit from which a typed answer counts as right,
+// so a typo in a long sentence does not cost the point.
+const typedAcceptCredit = 0.85
+
+// questionDirections are the directions a single question can be asked in,
+// MIXED picks one of them for every question.
+var questionDirections = []pb.Direction{
+	pb.Direction_JA_EN,
+	pb.Direction_JA_READING,
+	pb.Direction_EN_JA,
+}
+
+// answerColumns maps each direction to the quiz column holding its answer.
+var answerColumns = map[pb.Direction]string{
+	pb.Direction_JA_EN:      "english",
+	pb.Direction_JA_READING: "pronounce",
+	pb.Direction_EN_JA:      "japanese",
+}
+
+// questionDirection returns the direction quizId is asked in. MIXED is
+// resolved from the seed, so replaying a quiz asks the same way again.
+func questionDirection(seed, quizId int64, direction pb.Direction) pb.Direction {
+	if direction != pb.Direction_MIXED {
+		return direction
+	}
+	return questionDirections[quizRand(^seed, quizId).Intn(len(questionDirections))]
+}
+
+// expectedAnswer returns what the learner has to answer quiz with.
+func expectedAnswer(quiz *pb.Quiz, direction pb.Direction) string {
+	switch direction {
+	case pb.Direction_JA_READING:
+		return quiz.Pronounce
+	case pb.Direction_EN_JA:
+		return quiz.Japanese
+	default:
+		return quiz.English
+	}
+}
+
+// hideAnswer clears the answer, and whatever gives it away, so it is not
+// sent with the question.
+func hideAnswer(quiz *pb.Quiz, direction pb.Direction) {
+	switch direction {
+	case pb.Direction_JA_READING:
+		quiz.Pronounce = ""
+		quiz.English = ""
+	case pb.Direction_EN_JA:
+		quiz.Japanese = ""
+		quiz.Pronounce = ""
+	default:
+		quiz.English = ""
+	}
+}
+
+// gradeAnswer returns the credit of answer to question and whether it counts
+// as right. Options must be picked as they were sent, typed answers are
+// compared with grading so case, punctuation and the way a reading is spelled
+// do not matter. A japanese answer may also be typed as its reading.
+func gradeAnswer(format pb.AnswerFormat, question sessionQuestion, quiz *pb.Quiz, answer string) (float64, bool) {
+	expected := question.expected(quiz)
+	direction := question.Direction
+	if format == pb.AnswerFormat_CHOICE {
+		if answer == expected {
+			return 1, true
+		}
+		return 0, false
+	}
+
+	var credit float64
+	switch direction {
+	case pb.Direction_JA_READING:
+		credit = grading.Grade(grading.KindReading, expected, answer)
+	case pb.Direction_EN_JA:
+		credit = math.Max(
+			grading.Grade(grading.KindText, expected, answer),
+			grading.Grade(grading.KindReading, quiz.Pronounce, answer),
+		)
+	default:
+		credit = grading.Grade(grading.KindText, expected, answer)
+	}
+	return credit, credit >= typedAcceptCredit
+}
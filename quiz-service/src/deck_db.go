@@ -0,0 +1,286 @@
+package src
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Cprime50/quiz/db"
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+var (
+	ErrDeckNotFound = errors.New("deck not found")
+	ErrTagNotFound  = errors.New("tag not found")
+)
+
+// quizFilter narrows quizzes down to a deck and a tag. The zero value
+// matches every quiz.
+type quizFilter struct {
+	DeckId int64
+	Tag    string
+}
+
+// conditions returns the filter as conditions on the quiz table, each
+// starting with AND so they can follow any WHERE clause.
+func (f quizFilter) conditions() (string, []any) {
+	var cond strings.Builder
+	var args []any
+	if f.DeckId != 0 {
+		cond.WriteString(" AND quiz.id IN (SELECT quiz_id FROM quiz_decks WHERE deck_id = ?)")
+		args = append(args, f.DeckId)
+	}
+	if f.Tag != "" {
+		cond.WriteString(" AND quiz.id IN (SELECT quiz_tags.quiz_id FROM quiz_tags JOIN tags ON tags.id = quiz_tags.tag_id WHERE LOWER(tags.name) = LOWER(?))")
+		args = append(args, f.Tag)
+	}
+	return cond.String(), args
+}
+
+// SaveDeck creates the deck if it has no id and updates it otherwise.
+func (s *sqlStore) SaveDeck(deck *pb.Deck) error {
+	if deck.Id == 0 {
+		err := s.db.QueryRow("INSERT INTO decks (name, description) VALUES (?, ?) RETURNING id", deck.Name, deck.Description).Scan(&deck.Id)
+		if err != nil {
+			if s.isConstraintError(err) {
+				return ErrDuplicateEntry
+			}
+			return fmt.Errorf("CreateDeck error: %w", err)
+		}
+		return nil
+	}
+
+	result, err := s.db.Exec("UPDATE decks SET name = ?, description = ? WHERE id = ?", deck.Name, deck.Description, deck.Id)
+	if err != nil {
+		if s.isConstraintError(err) {
+			return ErrDuplicateEntry
+		}
+		return fmt.Errorf("UpdateDeck error: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrDeckNotFound
+	}
+	return nil
+}
+
+// DeleteDeck deletes the deck, its quizzes are only taken out of it.
+func (s *sqlStore) DeleteDeck(id int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("DELETE FROM decks WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("error deleting deck: %v", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrDeckNotFound
+	}
+	if _, err := tx.Exec("DELETE FROM quiz_decks WHERE deck_id = ?", id); err != nil {
+		return fmt.Errorf("error deleting deck: %v", err)
+	}
+	return tx.Commit()
+}
+
+// SelectDecks returns every deck with the number of quizzes in it.
+func (s *sqlStore) SelectDecks() ([]*pb.Deck, error) {
+	rows, err := s.db.Query(`
+        SELECT decks.id, decks.name, decks.description, COUNT(quiz.id)
+        FROM decks
+        LEFT JOIN quiz_decks ON quiz_decks.deck_id = decks.id
+        LEFT JOIN quiz ON quiz.id = quiz_decks.quiz_id AND quiz.deleted IS NULL
+        GROUP BY decks.id
+        ORDER BY decks.name`)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var decks []*pb.Deck
+	for rows.Next() {
+		deck := &pb.Deck{}
+		if err := rows.Scan(&deck.Id, &deck.Name, &deck.Description, &deck.QuizCount); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		decks = append(decks, deck)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	if len(decks) == 0 {
+		return nil, ErrDeckNotFound
+	}
+	return decks, nil
+}
+
+// AssignDeck adds the quizzes to the deck, or takes them out of it when
+// remove is set.
+func (s *sqlStore) AssignDeck(deckId int64, quizIds []int64, remove bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRow("SELECT 1 FROM decks WHERE id = ?", deckId).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrDeckNotFound
+		}
+		return fmt.Errorf("getDeck: %w", err)
+	}
+
+	for _, quizId := range quizIds {
+		if remove {
+			_, err = tx.Exec("DELETE FROM quiz_decks WHERE deck_id = ? AND quiz_id = ?", deckId, quizId)
+		} else {
+			if err := checkQuizExists(tx, quizId); err != nil {
+				return err
+			}
+			_, err = tx.Exec("INSERT INTO quiz_decks (deck_id, quiz_id) VALUES (?, ?) ON CONFLICT DO NOTHING", deckId, quizId)
+		}
+		if err != nil {
+			return fmt.Errorf("AssignDeck error: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// SelectTags returns every tag with the number of quizzes carrying it.
+func (s *sqlStore) SelectTags() ([]*pb.Tag, error) {
+	rows, err := s.db.Query(`
+        SELECT tags.name, COUNT(quiz.id)
+        FROM tags
+        LEFT JOIN quiz_tags ON quiz_tags.tag_id = tags.id
+        LEFT JOIN quiz ON quiz.id = quiz_tags.quiz_id AND quiz.deleted IS NULL
+        GROUP BY tags.id
+        ORDER BY tags.name`)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*pb.Tag
+	for rows.Next() {
+		tag := &pb.Tag{}
+		if err := rows.Scan(&tag.Name, &tag.QuizCount); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil, ErrTagNotFound
+	}
+	return tags, nil
+}
+
+// AssignTags tags the quizzes, creating tags on first use, or untags them
+// when remove is set.
+func (s *sqlStore) AssignTags(quizIds []int64, tags []string, remove bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, tag := range tags {
+		for _, quizId := range quizIds {
+			if remove {
+				_, err = tx.Exec("DELETE FROM quiz_tags WHERE quiz_id = ? AND tag_id = (SELECT id FROM tags WHERE LOWER(name) = LOWER(?))", quizId, tag)
+				if err != nil {
+					return fmt.Errorf("AssignTags error: %w", err)
+				}
+				continue
+			}
+			if err := checkQuizExists(tx, quizId); err != nil {
+				return err
+			}
+			if err := tagQuiz(tx, quizId, tag); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// tagQuiz tags the quiz, creating the tag on first use.
+func tagQuiz(ex execer, quizId int64, tag string) error {
+	if _, err := ex.Exec("INSERT INTO tags (name) VALUES (?) ON CONFLICT DO NOTHING", tag); err != nil {
+		return fmt.Errorf("CreateTag error: %w", err)
+	}
+	_, err := ex.Exec("INSERT INTO quiz_tags (quiz_id, tag_id) SELECT CAST(? AS BIGINT), id FROM tags WHERE LOWER(name) = LOWER(?) ON CONFLICT DO NOTHING", quizId, tag)
+	if err != nil {
+		return fmt.Errorf("AssignTags error: %w", err)
+	}
+	return nil
+}
+
+func checkQuizExists(tx *db.Tx, quizId int64) error {
+	var exists int
+	err := tx.QueryRow("SELECT 1 FROM quiz WHERE id = ? AND deleted IS NULL", quizId).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrQuizNotFound
+		}
+		return fmt.Errorf("getQuiz: %w", err)
+	}
+	return nil
+}
+
+// LoadQuizLabels fills in the decks and tags of quizzes.
+func (s *sqlStore) LoadQuizLabels(quizzes []*pb.Quiz) error {
+	if len(quizzes) == 0 {
+		return nil
+	}
+	byId := make(map[int64]*pb.Quiz, len(quizzes))
+	args := make([]any, 0, len(quizzes))
+	for _, quiz := range quizzes {
+		byId[quiz.Id] = quiz
+		args = append(args, quiz.Id)
+	}
+	placeholders := strings.Repeat(", ?", len(quizzes))[2:]
+
+	rows, err := s.db.Query("SELECT quiz_id, deck_id FROM quiz_decks WHERE quiz_id IN ("+placeholders+") ORDER BY deck_id", args...)
+	if err != nil {
+		return fmt.Errorf("db.Query: %w", err)
+	}
+	for rows.Next() {
+		var quizId, deckId int64
+		if err := rows.Scan(&quizId, &deckId); err != nil {
+			rows.Close()
+			return fmt.Errorf("rows.Scan: %w", err)
+		}
+		byId[quizId].DeckIds = append(byId[quizId].DeckIds, deckId)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("rows.Err: %w", err)
+	}
+
+	rows, err = s.db.Query("SELECT quiz_tags.quiz_id, tags.name FROM quiz_tags JOIN tags ON tags.id = quiz_tags.tag_id WHERE quiz_tags.quiz_id IN ("+placeholders+") ORDER BY tags.name", args...)
+	if err != nil {
+		return fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var quizId int64
+		var tag string
+		if err := rows.Scan(&quizId, &tag); err != nil {
+			return fmt.Errorf("rows.Scan: %w", err)
+		}
+		byId[quizId].Tags = append(byId[quizId].Tags, tag)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("rows.Err: %w", err)
+	}
+	return nil
+}
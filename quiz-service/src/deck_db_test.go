@@ -0,0 +1,177 @@
+package src
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+func TestSaveDeck(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	// Test case 1: Create a deck
+	deck := &pb.Deck{Name: "Animals", Description: "Pets and wild animals"}
+	if err := store.SaveDeck(deck); err != nil {
+		t.Fatalf("saveDeck error: %v", err)
+	}
+	if deck.Id == 0 {
+		t.Errorf("saveDeck error: id not set")
+	}
+
+	// Test case 2: Rename it
+	deck.Name = "Pets"
+	if err := store.SaveDeck(deck); err != nil {
+		t.Fatalf("saveDeck error: %v", err)
+	}
+	decks, err := store.SelectDecks()
+	if err != nil {
+		t.Fatalf("selectDecks error: %v", err)
+	}
+	if len(decks) != 1 || decks[0].Name != "Pets" {
+		t.Errorf("saveDeck error: update not applied, got %v", decks)
+	}
+
+	// Test case 3: Names are unique
+	err = store.SaveDeck(&pb.Deck{Name: "Pets"})
+	if !errors.Is(err, ErrDuplicateEntry) {
+		t.Errorf("saveDeck error: expected ErrDuplicateEntry, got %v", err)
+	}
+
+	// Test case 4: Update a deck that does not exist
+	err = store.SaveDeck(&pb.Deck{Id: 999999, Name: "Weather"})
+	if !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("saveDeck error: expected ErrDeckNotFound, got %v", err)
+	}
+}
+
+func TestAssignDeck(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+	deck := &pb.Deck{Name: "Pets"}
+	_ = store.SaveDeck(deck)
+
+	// Test case 1: Only the quizzes in the deck match its filter
+	err := store.AssignDeck(deck.Id, []int64{quizzes[0].Id, quizzes[1].Id}, false)
+	if err != nil {
+		t.Fatalf("assignDeck error: %v", err)
+	}
+	inDeck, err := store.SelectQuizzes(quizFilter{DeckId: deck.Id})
+	if err != nil {
+		t.Fatalf("selectQuizzes error: %v", err)
+	}
+	if len(inDeck) != 2 || inDeck[0].Id != quizzes[0].Id || inDeck[1].Id != quizzes[1].Id {
+		t.Errorf("selectQuizzes error: expected the 2 quizzes in the deck, got %v", inDeck)
+	}
+	decks, _ := store.SelectDecks()
+	if decks[0].QuizCount != 2 {
+		t.Errorf("selectDecks error: expected 2 quizzes in the deck, got %d", decks[0].QuizCount)
+	}
+
+	// Test case 2: Assigning twice is a no-op
+	if err := store.AssignDeck(deck.Id, []int64{quizzes[0].Id}, false); err != nil {
+		t.Errorf("assignDeck error: %v", err)
+	}
+
+	// Test case 3: Take a quiz out of the deck
+	if err := store.AssignDeck(deck.Id, []int64{quizzes[0].Id}, true); err != nil {
+		t.Fatalf("assignDeck error: %v", err)
+	}
+	inDeck, _ = store.SelectQuizzes(quizFilter{DeckId: deck.Id})
+	if len(inDeck) != 1 || inDeck[0].Id != quizzes[1].Id {
+		t.Errorf("assignDeck error: quiz not removed, got %v", inDeck)
+	}
+
+	// Test case 4: Unknown deck or quiz
+	err = store.AssignDeck(999999, []int64{quizzes[0].Id}, false)
+	if !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("assignDeck error: expected ErrDeckNotFound, got %v", err)
+	}
+	err = store.AssignDeck(deck.Id, []int64{999999}, false)
+	if !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("assignDeck error: expected ErrQuizNotFound, got %v", err)
+	}
+
+	// Test case 5: Deleting the deck keeps its quizzes
+	if err := store.DeleteDeck(deck.Id); err != nil {
+		t.Fatalf("deleteDeck error: %v", err)
+	}
+	if _, err := store.GetQuizById(quizzes[1].Id); err != nil {
+		t.Errorf("deleteDeck error: quiz deleted with the deck: %v", err)
+	}
+	if _, err := store.SelectDecks(); !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("selectDecks error: expected ErrDeckNotFound, got %v", err)
+	}
+	if err := store.DeleteDeck(deck.Id); !errors.Is(err, ErrDeckNotFound) {
+		t.Errorf("deleteDeck error: expected ErrDeckNotFound, got %v", err)
+	}
+}
+
+func TestAssignTags(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	// Test case 1: JLPT levels are there from the start
+	tags, err := store.SelectTags()
+	if err != nil {
+		t.Fatalf("selectTags error: %v", err)
+	}
+	levels := map[string]bool{}
+	for _, tag := range tags {
+		levels[tag.Name] = true
+	}
+	for _, level := range []string{"N5", "N4", "N3", "N2", "N1"} {
+		if !levels[level] {
+			t.Errorf("selectTags error: missing JLPT level %s", level)
+		}
+	}
+
+	// Test case 2: New tags are created on first use and match any case
+	err = store.AssignTags([]int64{quizzes[0].Id, quizzes[2].Id}, []string{"N5", "animals"}, false)
+	if err != nil {
+		t.Fatalf("assignTags error: %v", err)
+	}
+	tagged, err := store.SelectQuizzes(quizFilter{Tag: "Animals"})
+	if err != nil {
+		t.Fatalf("selectQuizzes error: %v", err)
+	}
+	if len(tagged) != 2 {
+		t.Errorf("selectQuizzes error: expected 2 tagged quizzes, got %d", len(tagged))
+	}
+
+	// Test case 3: Deck and tag filters combine
+	deck := &pb.Deck{Name: "Pets"}
+	_ = store.SaveDeck(deck)
+	_ = store.AssignDeck(deck.Id, []int64{quizzes[0].Id, quizzes[1].Id}, false)
+	both, _ := store.SelectQuizzes(quizFilter{DeckId: deck.Id, Tag: "N5"})
+	if len(both) != 1 || both[0].Id != quizzes[0].Id {
+		t.Errorf("selectQuizzes error: expected quiz %d, got %v", quizzes[0].Id, both)
+	}
+
+	// Test case 4: Labels are loaded onto the quizzes
+	if err := store.LoadQuizLabels(both); err != nil {
+		t.Fatalf("loadQuizLabels error: %v", err)
+	}
+	if len(both[0].DeckIds) != 1 || both[0].DeckIds[0] != deck.Id || len(both[0].Tags) != 2 {
+		t.Errorf("loadQuizLabels error: got decks %v and tags %v", both[0].DeckIds, both[0].Tags)
+	}
+
+	// Test case 5: Untag a quiz, and deleting a quiz drops its tags
+	_ = store.AssignTags([]int64{quizzes[0].Id}, []string{"animals"}, true)
+	_ = store.DeleteQuizzes([]int64{quizzes[2].Id}, testAuthor)
+	_, err = store.SelectQuizzes(quizFilter{Tag: "animals"})
+	if !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("selectQuizzes error: expected ErrQuizNotFound, got %v", err)
+	}
+
+	// Test case 6: Unknown quiz
+	err = store.AssignTags([]int64{999999}, []string{"N5"}, false)
+	if !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("assignTags error: expected ErrQuizNotFound, got %v", err)
+	}
+}
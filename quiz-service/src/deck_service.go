@@ -0,0 +1,156 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CreateUpdateDeck(ctx context.Context, req *pb.Deck) (*pb.Deck, error) {
+	start := time.Now()
+	req.Name = strings.TrimSpace(req.Name)
+	if err := validateDeck(req); err != nil {
+		s.logger.Error("CreateUpdateDeck error", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "deck validation error: %v", err)
+	}
+
+	deck := &pb.Deck{Id: req.Id, Name: req.Name, Description: req.Description}
+	if err := s.store.SaveDeck(deck); err != nil {
+		if errors.Is(err, ErrDuplicateEntry) {
+			s.logger.Error("CreateUpdateDeck error: deck already exists", "deck", deck.Name)
+			return nil, status.Errorf(codes.AlreadyExists, "deck already exists")
+		}
+		if errors.Is(err, ErrDeckNotFound) {
+			s.logger.Error("CreateUpdateDeck error: deck to update not found")
+			return nil, status.Errorf(codes.NotFound, "deck not found")
+		}
+		s.logger.Error("CreateUpdateDeck error: saving deck failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "error saving deck: %v", err)
+	}
+	s.logger.Info("CreateUpdateDeck successful: saved deck", "deck_id", deck.Id)
+	s.logger.Info("CreateUpdateDeck", "time", time.Since(start))
+	return deck, nil
+}
+
+func (s *Server) DeleteDeck(ctx context.Context, req *pb.DeleteDeckRequest) (*pb.Empty, error) {
+	start := time.Now()
+	if req.DeckId == 0 {
+		s.logger.Error("DeleteDeck error: missing deck ID")
+		return nil, status.Errorf(codes.InvalidArgument, "deck_id is required")
+	}
+
+	if err := s.store.DeleteDeck(req.DeckId); err != nil {
+		if errors.Is(err, ErrDeckNotFound) {
+			s.logger.Error("DeleteDeck error: deck not found")
+			return nil, status.Errorf(codes.NotFound, "deck not found")
+		}
+		s.logger.Error("DeleteDeck error: failed to delete deck", "error", err)
+		return nil, status.Errorf(codes.Internal, "error deleting deck: %v", err)
+	}
+	s.logger.Info("DeleteDeck successful: deleted deck", "deck_id", req.DeckId)
+	s.logger.Info("DeleteDeck", "time", time.Since(start))
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) ListDecks(req *pb.Empty, stream pb.QuizService_ListDecksServer) error {
+	start := time.Now()
+
+	decks, err := s.store.SelectDecks()
+	if err != nil {
+		if errors.Is(err, ErrDeckNotFound) {
+			s.logger.Error("ListDecks error: decks not found")
+			return status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("ListDecks error: failed to get decks", "error", err)
+		return status.Errorf(codes.Internal, "failed to get decks: %s", err)
+	}
+	for _, deck := range decks {
+		if err := stream.Send(deck); err != nil {
+			s.logger.Error("ListDecks error: failed to send decks to client", "error", err)
+			return status.Errorf(codes.Internal, "failed to send decks to client: %s", err)
+		}
+	}
+	s.logger.Info("ListDecks successful: sent decks", "count", len(decks))
+	s.logger.Info("ListDecks", "time", time.Since(start))
+	return nil
+}
+
+func (s *Server) AssignDeck(ctx context.Context, req *pb.AssignDeckRequest) (*pb.Empty, error) {
+	start := time.Now()
+	if req.DeckId == 0 {
+		s.logger.Error("AssignDeck error: missing deck ID")
+		return nil, status.Errorf(codes.InvalidArgument, "deck_id is required")
+	}
+	if len(req.QuizId) == 0 {
+		s.logger.Error("AssignDeck error: no quiz IDs in request")
+		return nil, status.Errorf(codes.InvalidArgument, "no quiz IDs in request")
+	}
+
+	if err := s.store.AssignDeck(req.DeckId, req.QuizId, req.Remove); err != nil {
+		if errors.Is(err, ErrDeckNotFound) {
+			s.logger.Error("AssignDeck error: deck not found")
+			return nil, status.Errorf(codes.NotFound, "deck not found")
+		}
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("AssignDeck error: quiz not found")
+			return nil, status.Errorf(codes.NotFound, "quiz not found")
+		}
+		s.logger.Error("AssignDeck error: failed to assign quizzes", "error", err)
+		return nil, status.Errorf(codes.Internal, "error assigning quizzes: %v", err)
+	}
+	s.logger.Info("AssignDeck successful: updated quizzes in deck", "count", len(req.QuizId), "deck_id", req.DeckId)
+	s.logger.Info("AssignDeck", "time", time.Since(start))
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) ListTags(req *pb.Empty, stream pb.QuizService_ListTagsServer) error {
+	start := time.Now()
+
+	tags, err := s.store.SelectTags()
+	if err != nil {
+		if errors.Is(err, ErrTagNotFound) {
+			s.logger.Error("ListTags error: tags not found")
+			return status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("ListTags error: failed to get tags", "error", err)
+		return status.Errorf(codes.Internal, "failed to get tags: %s", err)
+	}
+	for _, tag := range tags {
+		if err := stream.Send(tag); err != nil {
+			s.logger.Error("ListTags error: failed to send tags to client", "error", err)
+			return status.Errorf(codes.Internal, "failed to send tags to client: %s", err)
+		}
+	}
+	s.logger.Info("ListTags successful: sent tags", "count", len(tags))
+	s.logger.Info("ListTags", "time", time.Since(start))
+	return nil
+}
+
+func (s *Server) AssignTags(ctx context.Context, req *pb.AssignTagsRequest) (*pb.Empty, error) {
+	start := time.Now()
+	if len(req.QuizId) == 0 {
+		s.logger.Error("AssignTags error: no quiz IDs in request")
+		return nil, status.Errorf(codes.InvalidArgument, "no quiz IDs in request")
+	}
+	if err := validateTags(req.Tags); err != nil {
+		s.logger.Error("AssignTags error", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.store.AssignTags(req.QuizId, req.Tags, req.Remove); err != nil {
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("AssignTags error: quiz not found")
+			return nil, status.Errorf(codes.NotFound, "quiz not found")
+		}
+		s.logger.Error("AssignTags error: failed to tag quizzes", "error", err)
+		return nil, status.Errorf(codes.Internal, "error tagging quizzes: %v", err)
+	}
+	s.logger.Info("AssignTags successful: updated tags on quizzes", "tags", len(req.Tags), "quizzes", len(req.QuizId))
+	s.logger.Info("AssignTags", "time", time.Since(start))
+	return &pb.Empty{}, nil
+}
@@ -0,0 +1,114 @@
+package src
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+const (
+	// distractorCount is the number of wrong answers offered next to the
+	// correct one.
+	distractorCount = 3
+	// distractorWindow widens the set of closest candidates that distractors
+	// are drawn from, so the same sentence does not always get the same ones.
+	distractorWindow = 3
+)
+
+// newSeed returns a non-zero seed for a quiz that was not given one.
+func newSeed() int64 {
+	for {
+		if seed := rand.Int63(); seed != 0 {
+			return seed
+		}
+	}
+}
+
+// quizRand returns the random source for one question. It only depends on
+// the quiz seed and the question id, so a question gets the same options
+// whatever position it is served in.
+func quizRand(seed, quizId int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed ^ int64(uint64(quizId)*0x9E3779B97F4A7C15)))
+}
+
+// normalizeAnswer folds the differences that should not make two answers
+// count as different choices.
+func normalizeAnswer(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// pickDistractors returns up to n wrong answers for answer from pool. The
+// candidates closest in length to the answer are preferred, the correct
+// answer and duplicates are never returned.
+func pickDistractors(rng *rand.Rand, answer string, pool []string, n int) []string {
+	seen := map[string]bool{normalizeAnswer(answer): true}
+	var candidates []string
+	for _, c := range pool {
+		key := normalizeAnswer(c)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		candidates = append(candidates, c)
+	}
+
+	// Sort before drawing so the result does not depend on the pool order
+	length := utf8.RuneCountInString(answer)
+	distance := func(s string) int {
+		d := utf8.RuneCountInString(s) - length
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		di, dj := distance(candidates[i]), distance(candidates[j])
+		if di != dj {
+			return di < dj
+		}
+		return candidates[i] < candidates[j]
+	})
+
+	if window := n * distractorWindow; len(candidates) > window {
+		candidates = candidates[:window]
+	}
+	rng.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
+
+// buildOptions fills quiz.Options with the correct answer for direction and
+// its distractors in a seeded order, then clears the answer so it is not sent
+// to the client on its own. Distractors are drawn from pool, and from
+// fallback only when pool has too few of them.
+func buildOptions(seed int64, quiz *pb.Quiz, direction pb.Direction, pool, fallback []string) {
+	answer := expectedAnswer(quiz, direction)
+	rng := quizRand(seed, quiz.Id)
+	distractors := pickDistractors(rng, answer, pool, distractorCount)
+	if missing := distractorCount - len(distractors); missing > 0 && len(fallback) > 0 {
+		picked := make(map[string]bool, len(distractors))
+		for _, d := range distractors {
+			picked[normalizeAnswer(d)] = true
+		}
+		var rest []string
+		for _, c := range fallback {
+			if !picked[normalizeAnswer(c)] {
+				rest = append(rest, c)
+			}
+		}
+		distractors = append(distractors, pickDistractors(rng, answer, rest, missing)...)
+	}
+	options := append(distractors, answer)
+	rng.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+	quiz.Options = options
+	hideAnswer(quiz, direction)
+}
@@ -0,0 +1,107 @@
+package src
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+var answerPool = []string{
+	"Hello, world!",
+	"Good morning.",
+	"Good evening.",
+	"Thank you.",
+	"I'm sorry.",
+	"Please.",
+	"good morning.",
+	"Spring is coming soon.",
+	"Let's play outside on such a sunny day.",
+}
+
+func TestPickDistractors(t *testing.T) {
+	// Test case 1: Never returns the answer or duplicates
+	for seed := int64(1); seed < 50; seed++ {
+		distractors := pickDistractors(quizRand(seed, 1), "Good morning.", answerPool, 3)
+		if len(distractors) != 3 {
+			t.Fatalf("Expected 3 distractors, got %d", len(distractors))
+		}
+		seen := map[string]bool{}
+		for _, d := range distractors {
+			if normalizeAnswer(d) == "good morning." {
+				t.Errorf("seed %d: correct answer returned as distractor", seed)
+			}
+			if seen[d] {
+				t.Errorf("seed %d: duplicate distractor %s", seed, d)
+			}
+			seen[d] = true
+		}
+	}
+
+	// Test case 2: Prefers answers of similar length
+	distractors := pickDistractors(quizRand(7, 1), "Please.", answerPool, 1)
+	if distractors[0] == "Let's play outside on such a sunny day." {
+		t.Errorf("Expected a short distractor, got %s", distractors[0])
+	}
+
+	// Test case 3: Small pools return what they can
+	distractors = pickDistractors(quizRand(7, 1), "Thank you.", []string{"Thank you.", "Please."}, 3)
+	if len(distractors) != 1 {
+		t.Errorf("Expected 1 distractor, got %v", distractors)
+	}
+}
+
+func TestBuildOptionsDeterministic(t *testing.T) {
+	build := func(seed int64) []string {
+		quiz := &pb.Quiz{Id: 4, English: "Thank you."}
+		buildOptions(seed, quiz, pb.Direction_JA_EN, answerPool, nil)
+		if quiz.English != "" {
+			t.Errorf("buildOptions did not clear the answer")
+		}
+		return quiz.Options
+	}
+
+	first := build(42)
+	if len(first) != distractorCount+1 {
+		t.Fatalf("Expected %d options, got %d", distractorCount+1, len(first))
+	}
+	if !reflect.DeepEqual(first, build(42)) {
+		t.Errorf("Same seed produced different options")
+	}
+
+	found := false
+	for _, option := range first {
+		found = found || option == "Thank you."
+	}
+	if !found {
+		t.Errorf("Correct answer missing from options %v", first)
+	}
+}
+
+func TestBuildOptionsFallback(t *testing.T) {
+	deck := []string{"Thank you.", "Please.", "Good evening.", "I'm sorry."}
+
+	// Test case 1: A pool with enough answers is used alone
+	quiz := &pb.Quiz{Id: 4, English: "Thank you."}
+	buildOptions(42, quiz, pb.Direction_JA_EN, deck, answerPool)
+	for _, option := range quiz.Options {
+		if !slices.Contains(deck, option) {
+			t.Errorf("Expected options from the pool, got %v", quiz.Options)
+		}
+	}
+
+	// Test case 2: A small pool is completed from the fallback
+	quiz = &pb.Quiz{Id: 4, English: "Thank you."}
+	buildOptions(42, quiz, pb.Direction_JA_EN, deck[:2], answerPool)
+	if len(quiz.Options) != distractorCount+1 || !slices.Contains(quiz.Options, "Please.") {
+		t.Errorf("Expected Please. and %d other options, got %v", distractorCount, quiz.Options)
+	}
+	seen := map[string]bool{}
+	for _, option := range quiz.Options {
+		if seen[normalizeAnswer(option)] {
+			t.Errorf("Duplicate option %s in %v", option, quiz.Options)
+		}
+		seen[normalizeAnswer(option)] = true
+	}
+}
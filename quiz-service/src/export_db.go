@@ -0,0 +1,102 @@
+package src
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+// SelectAttempts returns every quiz handed out to userId, graded or not,
+// oldest first.
+func (s *sqlStore) SelectAttempts(userId string) ([]*pb.QuizAttempt, error) {
+	rows, err := s.db.Query("SELECT id, format, score, created_at, completed_at FROM quiz_sessions WHERE user_id = ? ORDER BY created_at, id", userId)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []*pb.QuizAttempt{}
+	byId := make(map[string]*pb.QuizAttempt)
+	for rows.Next() {
+		attempt := &pb.QuizAttempt{}
+		var score sql.NullInt64
+		var created time.Time
+		var completed sql.NullTime
+		if err := rows.Scan(&attempt.SessionId, &attempt.Format, &score, &created, &completed); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		attempt.Score = score.Int64
+		attempt.CreatedAt = created.Format(time.RFC3339)
+		if completed.Valid {
+			attempt.CompletedAt = completed.Time.Format(time.RFC3339)
+		}
+		attempts = append(attempts, attempt)
+		byId[attempt.SessionId] = attempt
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	rows, err = s.db.Query(`
+        SELECT quiz_session_questions.session_id, quiz_session_questions.quiz_id FROM quiz_session_questions
+        JOIN quiz_sessions ON quiz_sessions.id = quiz_session_questions.session_id
+        WHERE quiz_sessions.user_id = ?
+        ORDER BY quiz_session_questions.session_id, quiz_session_questions.position`,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sessionId string
+		var quizId int64
+		if err := rows.Scan(&sessionId, &quizId); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		if attempt, ok := byId[sessionId]; ok {
+			attempt.QuizIds = append(attempt.QuizIds, quizId)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return attempts, nil
+}
+
+// SelectUserReviewStates returns the review state of every quiz userId has
+// been graded on, deleted quizzes included.
+func (s *sqlStore) SelectUserReviewStates(userId string) ([]*pb.ReviewState, error) {
+	rows, err := s.db.Query(`
+        SELECT review_states.quiz_id, quiz.japanese, review_states.ease, review_states.interval_days,
+            review_states.repetitions, review_states.lapses, review_states.due_at, review_states.reviewed_at
+        FROM review_states
+        JOIN quiz ON quiz.id = review_states.quiz_id
+        WHERE review_states.user_id = ?
+        ORDER BY review_states.quiz_id`,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	states := []*pb.ReviewState{}
+	for rows.Next() {
+		state := &pb.ReviewState{}
+		var due, reviewed time.Time
+		if err := rows.Scan(&state.QuizId, &state.Japanese, &state.Ease, &state.IntervalDays, &state.Repetitions, &state.Lapses, &due, &reviewed); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		state.DueAt = due.Format(time.RFC3339)
+		state.ReviewedAt = reviewed.Format(time.RFC3339)
+		states = append(states, state)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return states, nil
+}
@@ -0,0 +1,40 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportMyData returns everything kept about a learner, for them to take a
+// copy of. A learner who never took a quiz gets an empty export.
+func (s *Server) ExportMyData(ctx context.Context, req *pb.ExportMyDataRequest) (*pb.QuizExport, error) {
+	start := time.Now()
+	if req.UserId == "" {
+		s.logger.Error("ExportMyData error: missing user ID")
+		return nil, status.Errorf(codes.InvalidArgument, "userId is required")
+	}
+
+	score, err := s.store.GetScoreByUserId(req.UserId)
+	if err != nil && !errors.Is(err, ErrScoreNotFound) {
+		s.logger.Error("ExportMyData error: failed to get score", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get score: %s", err)
+	}
+	attempts, err := s.store.SelectAttempts(req.UserId)
+	if err != nil {
+		s.logger.Error("ExportMyData error: failed to get attempts", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get attempts: %s", err)
+	}
+	states, err := s.store.SelectUserReviewStates(req.UserId)
+	if err != nil {
+		s.logger.Error("ExportMyData error: failed to get review states", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get review states: %s", err)
+	}
+	s.logger.Info("ExportMyData successful: exported learner data", "user_id", req.UserId, "attempts", len(attempts), "review_states", len(states))
+	s.logger.Info("ExportMyData", "time", time.Since(start))
+	return &pb.QuizExport{Score: score, Attempts: attempts, ReviewStates: states}, nil
+}
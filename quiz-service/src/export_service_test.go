@@ -0,0 +1,77 @@
+package src
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestExportMyData(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	// Test case 1: A learner who never took a quiz
+	export, err := s.ExportMyData(context.Background(), &pb.ExportMyDataRequest{UserId: "test1"})
+	if err != nil {
+		t.Fatalf("ExportMyData() error = %v", err)
+	}
+	if export.Score != 0 || len(export.Attempts) != 0 || len(export.ReviewStates) != 0 {
+		t.Errorf("ExportMyData() expected an empty export, got %v", export)
+	}
+
+	// Test case 2: One graded attempt and one left open
+	quiz, _ := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1", Mode: pb.QuizMode_REVIEW})
+	req := &pb.GetResultRequest{
+		UserId:    "test1",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}, {Id: quizzes[1].Id}, {Id: quizzes[2].Id}},
+		Answer:    []string{quizzes[0].English, quizzes[1].English, quizzes[2].English},
+	}
+	if _, err := s.GetResult(context.Background(), req); err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	for _, userId := range []string{"test1", "test2"} {
+		open := &quizSession{
+			UserId:    userId,
+			Seed:      1,
+			CreatedAt: time.Now().Add(time.Minute),
+			ExpiresAt: time.Now().Add(sessionTTL),
+			Questions: []sessionQuestion{{QuizId: quizzes[0].Id, Options: []string{quizzes[0].English}}},
+		}
+		_ = store.CreateSession(open)
+	}
+
+	export, err = s.ExportMyData(context.Background(), &pb.ExportMyDataRequest{UserId: "test1"})
+	if err != nil {
+		t.Fatalf("ExportMyData() error = %v", err)
+	}
+	if export.Score != 3 {
+		t.Errorf("ExportMyData() expected score 3, got %d", export.Score)
+	}
+	if len(export.Attempts) != 2 {
+		t.Fatalf("ExportMyData() expected 2 attempts, got %d", len(export.Attempts))
+	}
+	graded := export.Attempts[0]
+	if graded.SessionId != quiz.SessionId || graded.Score != 3 || graded.CompletedAt == "" || len(graded.QuizIds) != 3 {
+		t.Errorf("ExportMyData() unexpected graded attempt %v", graded)
+	}
+	if export.Attempts[1].CompletedAt != "" {
+		t.Errorf("ExportMyData() expected the second attempt open, got %v", export.Attempts[1])
+	}
+	if len(export.ReviewStates) != 3 || export.ReviewStates[0].Japanese != quizzes[0].Japanese || export.ReviewStates[2].Repetitions != 1 {
+		t.Errorf("ExportMyData() unexpected review states %v", export.ReviewStates)
+	}
+
+	// Test case 3: No user
+	_, err = s.ExportMyData(context.Background(), &pb.ExportMyDataRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ExportMyData() expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package src
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Cprime50/quiz/db"
+	"github.com/Cprime50/quiz/quizfile"
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+var ErrInvalidFile = errors.New("invalid file")
+
+// maxImportErrors is the number of skipped rows ImportQuizzes reports, the
+// others are only counted.
+const maxImportErrors = 1000
+
+// ImportQuizzes saves the quizzes read from r with their tags, as created by
+// authorId. Rows that are invalid or have a japanese, pronounce or english
+// already taken are skipped and reported, the others are saved in one
+// transaction.
+func (s *sqlStore) ImportQuizzes(r *quizfile.Reader, authorId string) (*pb.ImportQuizzesResponse, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	res := &pb.ImportQuizzesResponse{}
+	skip := func(importErr *pb.ImportError) {
+		if len(res.Errors) < maxImportErrors {
+			res.Errors = append(res.Errors, importErr)
+		}
+	}
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		var rowErr *quizfile.RowError
+		if errors.As(err, &rowErr) {
+			res.Invalid++
+			skip(&pb.ImportError{Line: int64(rowErr.Line), Error: rowErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+
+		quiz := &pb.Quiz{Japanese: rec.Japanese, Pronounce: rec.Pronounce, English: rec.English}
+		err = validateQuiz(quiz)
+		if err == nil && len(rec.Tags) > 0 {
+			err = validateTags(rec.Tags)
+		}
+		if err != nil {
+			res.Invalid++
+			skip(&pb.ImportError{Line: int64(r.Line()), Error: err.Error()})
+			continue
+		}
+
+		// A failed insert aborts the whole transaction on PostgreSQL, the
+		// savepoint lets the import go on past a duplicate
+		if _, err := tx.Exec("SAVEPOINT import_row"); err != nil {
+			return nil, fmt.Errorf("error importing quizzes: %v", err)
+		}
+		if err := s.createQuiz(tx, quiz, authorId); err != nil {
+			if !errors.Is(err, ErrDuplicateEntry) {
+				return nil, err
+			}
+			if _, err := tx.Exec("ROLLBACK TO SAVEPOINT import_row"); err != nil {
+				return nil, fmt.Errorf("error importing quizzes: %v", err)
+			}
+			duplicateOf, trashed, err := findDuplicate(tx, quiz)
+			if err != nil {
+				return nil, err
+			}
+			duplicate := ErrDuplicateEntry
+			if trashed {
+				duplicate = ErrDuplicateInTrash
+			}
+			res.Duplicates++
+			skip(&pb.ImportError{Line: int64(r.Line()), Error: duplicate.Error(), DuplicateOf: duplicateOf})
+			continue
+		}
+		if _, err := tx.Exec("RELEASE SAVEPOINT import_row"); err != nil {
+			return nil, fmt.Errorf("error importing quizzes: %v", err)
+		}
+		for _, tag := range rec.Tags {
+			if err := tagQuiz(tx, quiz.Id, tag); err != nil {
+				return nil, err
+			}
+		}
+		res.Imported++
+	}
+	return res, tx.Commit()
+}
+
+// findDuplicate returns the id of another quiz sharing a unique column with
+// quiz, live quizzes before the trash, and whether it is in the trash.
+func findDuplicate(tx *db.Tx, quiz *pb.Quiz) (int64, bool, error) {
+	var id int64
+	var trashed bool
+	err := tx.QueryRow(
+		`SELECT id, deleted IS NOT NULL FROM quiz
+        WHERE (japanese = ? OR pronounce = ? OR english = ?) AND id <> ?
+        ORDER BY deleted IS NOT NULL, id LIMIT 1`,
+		quiz.Japanese,
+		quiz.Pronounce,
+		quiz.English,
+		quiz.Id,
+	).Scan(&id, &trashed)
+	if err != nil && err != sql.ErrNoRows {
+		return 0, false, fmt.Errorf("findDuplicate: %w", err)
+	}
+	return id, trashed, nil
+}
+
+// duplicateError tells whether quiz would duplicate another quiz, live or in
+// the trash. It is checked before writing, a failed write aborts the whole
+// transaction on PostgreSQL.
+func duplicateError(tx *db.Tx, quiz *pb.Quiz) error {
+	id, trashed, err := findDuplicate(tx, quiz)
+	if err != nil {
+		return err
+	}
+	if trashed {
+		return ErrDuplicateInTrash
+	}
+	if id != 0 {
+		return ErrDuplicateEntry
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package src
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Cprime50/quiz/quizfile"
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxImportSize is the largest file ImportQuizzes accepts, in bytes.
+	maxImportSize = 16 << 20
+	// exportChunkSize is the size of the chunks ExportQuizzes streams.
+	exportChunkSize = 32 << 10
+)
+
+var fileFormats = map[pb.FileFormat]quizfile.Format{
+	pb.FileFormat_CSV:      quizfile.CSV,
+	pb.FileFormat_JSONL:    quizfile.JSONLines,
+	pb.FileFormat_ANKI_TSV: quizfile.AnkiTSV,
+}
+
+func (s *Server) ImportQuizzes(stream pb.QuizService_ImportQuizzesServer) error {
+	start := time.Now()
+
+	var file bytes.Buffer
+	var format pb.FileFormat
+	var authorId string
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.logger.Error("ImportQuizzes error: failed to receive file", "error", err)
+			return status.Errorf(codes.Internal, "failed to receive file: %v", err)
+		}
+		if first {
+			format = req.Format
+			authorId = req.AuthorId
+		}
+		if file.Len()+len(req.Chunk) > maxImportSize {
+			s.logger.Error("ImportQuizzes error: file too large", "max_size", maxImportSize)
+			return status.Errorf(codes.InvalidArgument, "file larger than %d bytes", maxImportSize)
+		}
+		file.Write(req.Chunk)
+	}
+
+	fileFormat, ok := fileFormats[format]
+	if !ok {
+		s.logger.Error("ImportQuizzes error: unknown format", "format", format)
+		return status.Errorf(codes.InvalidArgument, "unknown format: %v", format)
+	}
+	reader, err := quizfile.NewReader(&file, fileFormat)
+	if err != nil {
+		s.logger.Error("ImportQuizzes error", "error", err)
+		return status.Errorf(codes.InvalidArgument, "invalid file: %v", err)
+	}
+
+	res, err := s.store.ImportQuizzes(reader, authorId)
+	if err != nil {
+		if errors.Is(err, ErrInvalidFile) {
+			s.logger.Error("ImportQuizzes error", "error", err)
+			return status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Error("ImportQuizzes error: failed to import quizzes", "error", err)
+		return status.Errorf(codes.Internal, "error importing quizzes: %v", err)
+	}
+	s.logger.Info("ImportQuizzes successful: imported quizzes, skipped duplicates and invalid rows", "imported", res.Imported, "duplicates", res.Duplicates, "invalid", res.Invalid)
+	s.logger.Info("ImportQuizzes", "time", time.Since(start))
+	return stream.SendAndClose(res)
+}
+
+// chunkSender streams what is written to it as file chunks.
+type chunkSender struct {
+	stream pb.QuizService_ExportQuizzesServer
+}
+
+func (c chunkSender) Write(p []byte) (int, error) {
+	if err := c.stream.Send(&pb.FileChunk{Chunk: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *Server) ExportQuizzes(req *pb.ExportQuizzesRequest, stream pb.QuizService_ExportQuizzesServer) error {
+	start := time.Now()
+	fileFormat, ok := fileFormats[req.Format]
+	if !ok {
+		s.logger.Error("ExportQuizzes error: unknown format", "format", req.Format)
+		return status.Errorf(codes.InvalidArgument, "unknown format: %v", req.Format)
+	}
+
+	quizzes, err := s.store.SelectQuizzes(quizFilter{DeckId: req.DeckId, Tag: strings.TrimSpace(req.Tag)})
+	if err != nil {
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("ExportQuizzes error: quizzes not found")
+			return status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("ExportQuizzes error: failed to get quizzes", "error", err)
+		return status.Errorf(codes.Internal, "failed to get quizzes: %s", err)
+	}
+	if err := s.store.LoadQuizLabels(quizzes); err != nil {
+		s.logger.Error("ExportQuizzes error: failed to get tags", "error", err)
+		return status.Errorf(codes.Internal, "failed to get tags: %s", err)
+	}
+
+	if err := writeQuizzes(chunkSender{stream: stream}, fileFormat, quizzes); err != nil {
+		s.logger.Error("ExportQuizzes error: failed to send file to client", "error", err)
+		return status.Errorf(codes.Internal, "failed to send file to client: %s", err)
+	}
+	s.logger.Info("ExportQuizzes successful: sent quizzes", "count", len(quizzes))
+	s.logger.Info("ExportQuizzes", "time", time.Since(start))
+	return nil
+}
+
+// writeQuizzes writes quizzes to out as a file in format, in chunks of
+// exportChunkSize.
+func writeQuizzes(out io.Writer, format quizfile.Format, quizzes []*pb.Quiz) error {
+	buf := bufio.NewWriterSize(out, exportChunkSize)
+	writer, err := quizfile.NewWriter(buf, format)
+	if err != nil {
+		return err
+	}
+	for _, quiz := range quizzes {
+		err := writer.Write(quizfile.Record{
+			Japanese:  quiz.Japanese,
+			Pronounce: quiz.Pronounce,
+			English:   quiz.English,
+			Tags:      quiz.Tags,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
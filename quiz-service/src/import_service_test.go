@@ -0,0 +1,115 @@
+package src
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockQuizService_ImportQuizzesServer struct {
+	grpc.ServerStream
+	Requests []*pb.ImportQuizzesRequest
+	Result   *pb.ImportQuizzesResponse
+}
+
+func (_m *mockQuizService_ImportQuizzesServer) Recv() (*pb.ImportQuizzesRequest, error) {
+	if len(_m.Requests) == 0 {
+		return nil, io.EOF
+	}
+	req := _m.Requests[0]
+	_m.Requests = _m.Requests[1:]
+	return req, nil
+}
+
+func (_m *mockQuizService_ImportQuizzesServer) SendAndClose(res *pb.ImportQuizzesResponse) error {
+	_m.Result = res
+	return nil
+}
+
+type mockQuizService_ExportQuizzesServer struct {
+	grpc.ServerStream
+	File bytes.Buffer
+}
+
+func (_m *mockQuizService_ExportQuizzesServer) Send(c *pb.FileChunk) error {
+	_m.File.Write(c.Chunk)
+	return nil
+}
+
+// importFile sends file to ImportQuizzes in chunks of 16 bytes.
+func importFile(s *Server, format pb.FileFormat, file string) (*pb.ImportQuizzesResponse, error) {
+	mock := &mockQuizService_ImportQuizzesServer{}
+	for i := 0; i < len(file); i += 16 {
+		chunk := []byte(file[i:min(i+16, len(file))])
+		mock.Requests = append(mock.Requests, &pb.ImportQuizzesRequest{Format: format, Chunk: chunk})
+	}
+	err := s.ImportQuizzes(mock)
+	return mock.Result, err
+}
+
+func TestImportQuizzes(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes[:1], testAuthor)
+
+	file := "japanese,pronounce,english,tags\n" +
+		"犬です。,Inu desu.,It's a dog.,N5 animals\n" +
+		"猫です。,Neko,It's a kitten.,\n" +
+		",Tori desu.,It's a bird.,\n" +
+		"鳥です。,Tori desu.,It's a bird.,N5\n" +
+		"鳥です。,Tori desu.,A bird.,\n"
+
+	// Test case 1: Valid rows are imported, the others reported by line
+	res, err := importFile(s, pb.FileFormat_CSV, file)
+	if err != nil {
+		t.Fatalf("ImportQuizzes() error = %v", err)
+	}
+	if res.Imported != 2 || res.Duplicates != 2 || res.Invalid != 1 {
+		t.Fatalf("ImportQuizzes() expected 2 imported, 2 duplicates and 1 invalid, got %+v", res)
+	}
+	if res.Errors[0].Line != 3 || res.Errors[0].DuplicateOf != quizzes[0].Id {
+		t.Errorf("ImportQuizzes() expected line 3 to duplicate quiz %d, got %+v", quizzes[0].Id, res.Errors[0])
+	}
+	if res.Errors[1].Line != 4 || res.Errors[1].DuplicateOf != 0 {
+		t.Errorf("ImportQuizzes() expected line 4 to be invalid, got %+v", res.Errors[1])
+	}
+	if res.Errors[2].Line != 6 || res.Errors[2].DuplicateOf == 0 {
+		t.Errorf("ImportQuizzes() expected line 6 to duplicate line 5, got %+v", res.Errors[2])
+	}
+	tagged, _ := store.SelectQuizzes(quizFilter{Tag: "N5"})
+	if len(tagged) != 2 {
+		t.Errorf("ImportQuizzes() expected 2 quizzes tagged N5, got %d", len(tagged))
+	}
+
+	// Test case 2: Export in every format and import again
+	for _, format := range []pb.FileFormat{pb.FileFormat_CSV, pb.FileFormat_JSONL, pb.FileFormat_ANKI_TSV} {
+		mock := &mockQuizService_ExportQuizzesServer{}
+		if err := s.ExportQuizzes(&pb.ExportQuizzesRequest{Format: format, Tag: "N5"}, mock); err != nil {
+			t.Fatalf("ExportQuizzes() error = %v", err)
+		}
+		if !strings.Contains(mock.File.String(), "Inu desu.") {
+			t.Errorf("ExportQuizzes() %v missing a quiz: %s", format, mock.File.String())
+		}
+		res, err := importFile(s, format, mock.File.String())
+		if err != nil {
+			t.Fatalf("ImportQuizzes() error = %v", err)
+		}
+		if res.Imported != 0 || res.Duplicates != 2 {
+			t.Errorf("ImportQuizzes() %v expected 2 duplicates, got %+v", format, res)
+		}
+	}
+
+	// Test case 3: Unknown format
+	_, err = importFile(s, pb.FileFormat(9), file)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ImportQuizzes() expected InvalidArgument, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package src
+
+import (
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+// windowStart returns when the leaderboard window containing now began.
+// Days and weeks start at midnight UTC, weeks on Monday.
+func windowStart(window pb.LeaderBoardWindow, now time.Time) (time.Time, bool) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch window {
+	case pb.LeaderBoardWindow_ALL_TIME:
+		return time.Time{}, true
+	case pb.LeaderBoardWindow_DAY:
+		return today, true
+	case pb.LeaderBoardWindow_WEEK:
+		daysSinceMonday := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -daysSinceMonday), true
+	}
+	return time.Time{}, false
+}
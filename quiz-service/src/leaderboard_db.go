@@ -0,0 +1,75 @@
+package src
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+func recordScoreEvent(ex execer, userId, sessionId string, points int64, at time.Time) error {
+	_, err := ex.Exec(
+		"INSERT INTO score_events (user_id, session_id, points, created_at) VALUES (?, ?, ?, ?)",
+		userId,
+		sessionId,
+		points,
+		at,
+	)
+	if err != nil {
+		return fmt.Errorf("error recording score event: %v", err)
+	}
+	return nil
+}
+
+// SelectLeaderBoard ranks learners by the points they earned since the given
+// time. Ties go to whoever reached their total first, then by user ID, so
+// the ranking is the same on every call. It returns the top limit entries
+// followed by the entry of userId when they are not among them.
+func (s *sqlStore) SelectLeaderBoard(since time.Time, userId string, limit int) ([]*pb.LeaderBoard, error) {
+	rows, err := s.db.Query(`
+        WITH totals AS (
+            SELECT score_events.user_id, COALESCE(scores.username, '') AS username,
+                SUM(score_events.points) AS points, MAX(score_events.created_at) AS reached_at
+            FROM score_events
+            LEFT JOIN scores ON scores.user_id = score_events.user_id
+            WHERE score_events.created_at >= ?
+            GROUP BY score_events.user_id, scores.username
+        ), ranked AS (
+            SELECT user_id, username, points,
+                ROW_NUMBER() OVER (ORDER BY points DESC, reached_at ASC, user_id ASC) AS rank
+            FROM totals
+        )
+        SELECT user_id, username, points, rank FROM ranked
+        WHERE rank <= ? OR user_id = ?
+        ORDER BY rank`,
+		since,
+		limit,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var leaderBoard []*pb.LeaderBoard
+	for rows.Next() {
+		entry := &pb.LeaderBoard{}
+		if err := rows.Scan(&entry.UserId, &entry.Username, &entry.Score, &entry.Rank); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		if entry.Username == "" {
+			entry.Username = entry.UserId
+		}
+		entry.CurrentUser = userId != "" && entry.UserId == userId
+		leaderBoard = append(leaderBoard, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	if len(leaderBoard) == 0 {
+		return nil, ErrScoreNotFound
+	}
+
+	return leaderBoard, nil
+}
@@ -0,0 +1,76 @@
+package src
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+func TestWindowStart(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2024, 3, 13, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		window   pb.LeaderBoardWindow
+		expected time.Time
+	}{
+		{pb.LeaderBoardWindow_ALL_TIME, time.Time{}},
+		{pb.LeaderBoardWindow_DAY, time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC)},
+		{pb.LeaderBoardWindow_WEEK, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		start, ok := windowStart(test.window, now)
+		if !ok || !start.Equal(test.expected) {
+			t.Errorf("For %v, expected %v, got %v", test.window, test.expected, start)
+		}
+	}
+
+	// Sundays belong to the week that started on Monday
+	start, _ := windowStart(pb.LeaderBoardWindow_WEEK, time.Date(2024, 3, 17, 23, 0, 0, 0, time.UTC))
+	if !start.Equal(time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected Sunday to be in the week of Monday 11th, got %v", start)
+	}
+
+	if _, ok := windowStart(pb.LeaderBoardWindow(42), now); ok {
+		t.Errorf("Expected unknown window to be rejected")
+	}
+}
+
+func TestSelectLeaderBoard(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	now := time.Now().UTC()
+
+	_ = addScore(store.db, "test1", "Username1", 16)
+	_ = recordScoreEvent(store.db, "test1", "session1", 10, now.Add(-2*time.Hour))
+	_ = recordScoreEvent(store.db, "test1", "session2", 6, now.Add(-time.Hour))
+	_ = recordScoreEvent(store.db, "test2", "session3", 16, now.Add(-3*time.Hour))
+	_ = recordScoreEvent(store.db, "test3", "session4", 20, now.AddDate(0, 0, -30))
+
+	// Test case 1: Ties go to whoever reached the score first
+	leaderBoard, err := store.SelectLeaderBoard(time.Time{}, "", 10)
+	if err != nil {
+		t.Fatalf("selectLeaderBoard error: %v", err)
+	}
+	expected := []string{"test3", "test2", "Username1"}
+	if len(leaderBoard) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(leaderBoard))
+	}
+	for i, entry := range leaderBoard {
+		if entry.Username != expected[i] || entry.Rank != int64(i+1) {
+			t.Errorf("Entry %d: expected %s, got %s at rank %d", i, expected[i], entry.Username, entry.Rank)
+		}
+	}
+
+	// Test case 2: Only points inside the window count
+	leaderBoard, _ = store.SelectLeaderBoard(now.Add(-90*time.Minute), "", 10)
+	if len(leaderBoard) != 1 || leaderBoard[0].UserId != "test1" || leaderBoard[0].Score != 6 {
+		t.Errorf("Expected test1 with 6 points, got %v", leaderBoard)
+	}
+
+	// Test case 3: Caller outside the top entries is appended
+	leaderBoard, _ = store.SelectLeaderBoard(time.Time{}, "test1", 1)
+	if len(leaderBoard) != 2 || !leaderBoard[1].CurrentUser || leaderBoard[1].Rank != 3 {
+		t.Errorf("Expected caller at rank 3 after the top entry, got %v", leaderBoard)
+	}
+}
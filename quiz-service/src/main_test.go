@@ -0,0 +1,138 @@
+package src
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Cprime50/quiz/db"
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+)
+
+// embeddedPostgresPort is where the PostgreSQL started for this package's
+// tests listens, apart from the one profile-service tests start.
+const embeddedPostgresPort = 5434
+
+var (
+	// postgresUrl is the PostgreSQL the tests create their databases in,
+	// empty when they run on SQLite.
+	postgresUrl string
+	postgresDbs atomic.Int64
+)
+
+// startTestPostgres returns the PostgreSQL to run the tests against when
+// TEST_DATABASE=postgres: TEST_DATABASE_URL, or else one started for the
+// run and stopped by the returned func.
+func startTestPostgres() (string, func(), error) {
+	if url := os.Getenv("TEST_DATABASE_URL"); url != "" {
+		return url, func() {}, nil
+	}
+	dir, err := os.MkdirTemp("", "quiz-postgres")
+	if err != nil {
+		return "", nil, err
+	}
+	config := embeddedpostgres.DefaultConfig().
+		Port(embeddedPostgresPort).
+		RuntimePath(dir).
+		Logger(nil)
+	postgres := embeddedpostgres.NewDatabase(config)
+	if err := postgres.Start(); err != nil {
+		os.RemoveAll(dir)
+		return "", nil, err
+	}
+	stop := func() {
+		postgres.Stop()
+		os.RemoveAll(dir)
+	}
+	return config.GetConnectionURL() + "?sslmode=disable", stop, nil
+}
+
+// openTestDb opens a new, migrated database for one test: SQLite in memory,
+// or a database of its own in postgresUrl.
+func openTestDb() (*sql.DB, db.Dialect, func(), error) {
+	var conn *sql.DB
+	var dialect db.Dialect
+	var err error
+	drop := func() {}
+	if postgresUrl == "" {
+		conn, dialect, err = db.ConnectTest()
+	} else {
+		conn, dialect, drop, err = createPostgresDb()
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	closeDb := func() {
+		conn.Close()
+		drop()
+	}
+	if _, err := db.Migrate(conn, dialect); err != nil {
+		closeDb()
+		return nil, nil, nil, err
+	}
+	return conn, dialect, closeDb, nil
+}
+
+// createPostgresDb creates a database in postgresUrl and opens it. The
+// returned func drops it once it has been closed.
+func createPostgresDb() (*sql.DB, db.Dialect, func(), error) {
+	admin, _, err := db.Open(postgresUrl)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	name := fmt.Sprintf("quiz_test_%d_%d", os.Getpid(), postgresDbs.Add(1))
+	if _, err := admin.Exec("CREATE DATABASE " + name); err != nil {
+		admin.Close()
+		return nil, nil, nil, err
+	}
+	drop := func() {
+		admin.Exec("DROP DATABASE IF EXISTS " + name)
+		admin.Close()
+	}
+
+	u, err := url.Parse(postgresUrl)
+	if err != nil {
+		drop()
+		return nil, nil, nil, err
+	}
+	u.Path = "/" + name
+	conn, dialect, err := db.Open(u.String())
+	if err != nil {
+		drop()
+		return nil, nil, nil, err
+	}
+	return conn, dialect, drop, nil
+}
+
+// newTestStore returns a store on a database of the test's own, so tests
+// can run in parallel.
+func newTestStore(t *testing.T) *sqlStore {
+	t.Helper()
+	conn, dialect, closeDb, err := openTestDb()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(closeDb)
+	return NewQuizStore(conn, dialect).(*sqlStore)
+}
+
+func TestMain(m *testing.M) {
+
+	log.Println("Running tests...")
+	stop := func() {}
+	if os.Getenv("TEST_DATABASE") == "postgres" {
+		var err error
+		postgresUrl, stop, err = startTestPostgres()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
@@ -1,31 +1,290 @@
 package src
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/Cprime50/quiz/db"
 	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
-	ErrProfileNotFound           = errors.New("not found")
+	ErrQuizNotFound              = errors.New("quiz not found")
+	ErrScoreNotFound             = errors.New("score not found")
 	ErrDuplicateEntry            = errors.New("duplicate entry")
 	ErrForeignKeyViolation       = errors.New("foreign key violation")
 	ErrUniqueConstraintViolation = errors.New("unique constraint violation")
+	// ErrDuplicateInTrash is a duplicate of a quiz in the trash, which is
+	// restored rather than created again.
+	ErrDuplicateInTrash = fmt.Errorf("%w of a quiz in the trash", ErrDuplicateEntry)
 )
 
-func getQuizByUserId(userId string) (*pb.Quiz, error) {
+const quizColumns = "id, japanese, pronounce, english, created, updated, deleted"
 
+type rowScanner interface {
+	Scan(dest ...any) error
 }
 
-func createQuiz(q *pb.Quiz) error {
+// execer is satisfied by both *sql.DB and *db.Tx.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func scanQuiz(row rowScanner) (*pb.Quiz, error) {
+	quiz := &pb.Quiz{}
+	var created, updated time.Time
+	var deleted sql.NullTime
+	if err := row.Scan(&quiz.Id, &quiz.Japanese, &quiz.Pronounce, &quiz.English, &created, &updated, &deleted); err != nil {
+		return nil, err
+	}
+	quiz.CreatedAt = created.Format(time.RFC3339)
+	quiz.UpdatedAt = updated.Format(time.RFC3339)
+	if deleted.Valid {
+		quiz.DeletedAt = deleted.Time.Format(time.RFC3339)
+	}
+	return quiz, nil
+}
+
+func scanQuizzes(rows *sql.Rows) ([]*pb.Quiz, error) {
+	defer rows.Close()
+
+	var quizzes []*pb.Quiz
+	for rows.Next() {
+		quiz, err := scanQuiz(rows)
+		if err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		quizzes = append(quizzes, quiz)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	if len(quizzes) == 0 {
+		return nil, ErrQuizNotFound
+	}
+	return quizzes, nil
+}
+
+func (s *sqlStore) GetQuizById(id int64) (*pb.Quiz, error) {
+	quiz, err := scanQuiz(s.db.QueryRow("SELECT "+quizColumns+" FROM quiz WHERE id = ? AND deleted IS NULL", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrQuizNotFound
+		}
+		return nil, fmt.Errorf("getQuiz: %w", err)
+	}
+	return quiz, nil
+}
+
+// GetQuizWithDeleted returns a quiz whether or not it is in the trash, so
+// sessions that issued it before it was deleted can still be graded.
+func (s *sqlStore) GetQuizWithDeleted(id int64) (*pb.Quiz, error) {
+	quiz, err := scanQuiz(s.db.QueryRow("SELECT "+quizColumns+" FROM quiz WHERE id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrQuizNotFound
+		}
+		return nil, fmt.Errorf("getQuiz: %w", err)
+	}
+	return quiz, nil
+}
+
+// SelectQuizzesAfter returns up to limit quizzes matching filter whose id is
+// greater than progress, which is how a learner's score maps onto the
+// content they have not reached yet.
+func (s *sqlStore) SelectQuizzesAfter(progress int64, filter quizFilter, limit int) ([]*pb.Quiz, error) {
+	cond, args := filter.conditions()
+	args = append([]any{progress}, args...)
+	args = append(args, limit)
+	rows, err := s.db.Query("SELECT "+quizColumns+" FROM quiz WHERE id > ? AND deleted IS NULL"+cond+" ORDER BY id LIMIT ?", args...)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	return scanQuizzes(rows)
+}
+
+func (s *sqlStore) SelectQuizzes(filter quizFilter) ([]*pb.Quiz, error) {
+	cond, args := filter.conditions()
+	rows, err := s.db.Query("SELECT "+quizColumns+" FROM quiz WHERE deleted IS NULL"+cond+" ORDER BY id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	return scanQuizzes(rows)
+}
 
+// SelectAnswers returns the answers for direction of the quizzes matching
+// filter, the pool distractors are drawn from.
+func (s *sqlStore) SelectAnswers(direction pb.Direction, filter quizFilter) ([]string, error) {
+	column, ok := answerColumns[direction]
+	if !ok {
+		return nil, fmt.Errorf("no answers for direction: %v", direction)
+	}
+	cond, args := filter.conditions()
+	rows, err := s.db.Query("SELECT "+column+" FROM quiz WHERE deleted IS NULL"+cond+" ORDER BY id", args...)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []string
+	for rows.Next() {
+		var answer string
+		if err := rows.Scan(&answer); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		answers = append(answers, answer)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return answers, nil
 }
 
-func updateQuiz(q *pb.Quiz) error {
+// SaveQuizzes creates quizzes without an id and updates the rest in a single
+// transaction, so a bad row leaves the table untouched. Every change is
+// recorded as a revision by authorId.
+func (s *sqlStore) SaveQuizzes(quizzes []*pb.Quiz, authorId string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
 
+	for _, q := range quizzes {
+		if q.Id == 0 {
+			err = s.createQuiz(tx, q, authorId)
+		} else {
+			err = s.updateQuiz(tx, q, authorId)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
-func selectLeaderBoard() ([]*pb.LeaderBoard, error){
+func (s *sqlStore) createQuiz(tx *db.Tx, q *pb.Quiz, authorId string) error {
+	if err := duplicateError(tx, q); err != nil {
+		return err
+	}
+	err := tx.QueryRow(
+		"INSERT INTO quiz (japanese, pronounce, english) VALUES (?, ?, ?) RETURNING id",
+		q.Japanese,
+		q.Pronounce,
+		q.English,
+	).Scan(&q.Id)
+	if err != nil {
+		if s.isConstraintError(err) {
+			return ErrDuplicateEntry
+		}
+		return fmt.Errorf("CreateQuiz error: %w", err)
+	}
+	return recordRevision(tx, &pb.QuizRevision{
+		QuizId:   q.Id,
+		Action:   pb.RevisionAction_CREATE,
+		AuthorId: authorId,
+		After:    quizContent(q),
+	}, time.Now().UTC())
+}
 
+func (s *sqlStore) updateQuiz(tx *db.Tx, q *pb.Quiz, authorId string) error {
+	before, err := getQuizContent(tx, q.Id)
+	if err != nil {
+		return err
+	}
+	if err := duplicateError(tx, q); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	_, err = tx.Exec(
+		"UPDATE quiz SET japanese = ?, pronounce = ?, english = ?, updated = ? WHERE id = ?",
+		q.Japanese,
+		q.Pronounce,
+		q.English,
+		now,
+		q.Id,
+	)
+	if err != nil {
+		if s.isConstraintError(err) {
+			return ErrDuplicateEntry
+		}
+		return fmt.Errorf("UpdateQuiz error: %w", err)
+	}
+	after := quizContent(q)
+	if proto.Equal(before, after) {
+		return nil
+	}
+	return recordRevision(tx, &pb.QuizRevision{
+		QuizId:   q.Id,
+		Action:   pb.RevisionAction_UPDATE,
+		AuthorId: authorId,
+		Before:   before,
+		After:    after,
+	}, now)
 }
 
+// DeleteQuizzes moves the quizzes to the trash. They keep their decks, tags
+// and reviews until they are restored or purged.
+func (s *sqlStore) DeleteQuizzes(ids []int64, authorId string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	for _, id := range ids {
+		before, err := getQuizContent(tx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec("UPDATE quiz SET deleted = ? WHERE id = ?", now, id); err != nil {
+			return fmt.Errorf("error deleting quiz: %v", err)
+		}
+		err = recordRevision(tx, &pb.QuizRevision{
+			QuizId:   id,
+			Action:   pb.RevisionAction_DELETE,
+			AuthorId: authorId,
+			Before:   before,
+		}, now)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *sqlStore) GetScoreByUserId(userId string) (int64, error) {
+	var score int64
+	err := s.db.QueryRow("SELECT score FROM scores WHERE user_id = ?", userId).Scan(&score)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrScoreNotFound
+		}
+		return 0, fmt.Errorf("getScore: %w", err)
+	}
+	return score, nil
+}
+
+// addScore adds points to the learner's score, creating the row on their
+// first graded quiz. An empty username keeps the one already stored.
+func addScore(ex execer, userId, username string, points int64) error {
+	_, err := ex.Exec(`
+        INSERT INTO scores (user_id, username, score, updated_at) VALUES (?, ?, ?, ?)
+        ON CONFLICT (user_id) DO UPDATE SET
+            score = scores.score + excluded.score,
+            username = CASE WHEN excluded.username = '' THEN scores.username ELSE excluded.username END,
+            updated_at = excluded.updated_at`,
+		userId,
+		username,
+		points,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating score: %v", err)
+	}
+	return nil
+}
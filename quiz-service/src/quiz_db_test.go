@@ -0,0 +1,136 @@
+package src
+
+import (
+	"errors"
+	"testing"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+// testAuthor is the user the tests edit quizzes as.
+const testAuthor = "admin1"
+
+func testQuizzes() []*pb.Quiz {
+	return []*pb.Quiz{
+		{Japanese: "猫です。", Pronounce: "Neko desu.", English: "It's a cat."},
+		{Japanese: "犬です。", Pronounce: "Inu desu.", English: "It's a dog."},
+		{Japanese: "鳥です。", Pronounce: "Tori desu.", English: "It's a bird."},
+	}
+}
+
+func TestSaveQuizzes(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+
+	// Test case 1: Insert new quizzes
+	err := store.SaveQuizzes(quizzes, testAuthor)
+	if err != nil {
+		t.Fatalf("saveQuizzes error: %v", err)
+	}
+	for _, q := range quizzes {
+		if q.Id == 0 {
+			t.Errorf("saveQuizzes error: id not set on %s", q.Japanese)
+		}
+	}
+	gottenQuiz, err := store.GetQuizById(quizzes[0].Id)
+	if err != nil {
+		t.Fatalf("getQuizById error: %v", err)
+	}
+	if gottenQuiz.English != quizzes[0].English || gottenQuiz.CreatedAt == "" {
+		t.Errorf("getQuizById error: not equal")
+	}
+
+	// Test case 2: Update an existing quiz
+	quizzes[0].English = "That is a cat."
+	err = store.SaveQuizzes(quizzes[:1], testAuthor)
+	if err != nil {
+		t.Fatalf("saveQuizzes error: %v", err)
+	}
+	gottenQuiz, _ = store.GetQuizById(quizzes[0].Id)
+	if gottenQuiz.English != "That is a cat." {
+		t.Errorf("saveQuizzes error: update not applied")
+	}
+
+	// Test case 3: A duplicate rolls back the whole batch
+	batch := []*pb.Quiz{
+		{Japanese: "魚です。", Pronounce: "Sakana desu.", English: "It's a fish."},
+		{Japanese: "犬です。", Pronounce: "Inu desu.", English: "It's a dog."},
+	}
+	err = store.SaveQuizzes(batch, testAuthor)
+	if !errors.Is(err, ErrDuplicateEntry) {
+		t.Errorf("saveQuizzes error: expected ErrDuplicateEntry, got %v", err)
+	}
+	all, _ := store.SelectQuizzes(quizFilter{})
+	if len(all) != len(quizzes) {
+		t.Errorf("Expected %d quizzes after rollback, got %d", len(quizzes), len(all))
+	}
+
+	// Test case 4: Update a quiz that does not exist
+	err = store.SaveQuizzes([]*pb.Quiz{{Id: 999999, Japanese: "a", Pronounce: "a", English: "a"}}, testAuthor)
+	if !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("saveQuizzes error: expected ErrQuizNotFound, got %v", err)
+	}
+}
+
+func TestSelectQuizzesAfter(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	gottenQuizzes, err := store.SelectQuizzesAfter(quizzes[0].Id, quizFilter{}, 1)
+	if err != nil {
+		t.Fatalf("selectQuizzesAfter error: %v", err)
+	}
+	if len(gottenQuizzes) != 1 || gottenQuizzes[0].Id != quizzes[1].Id {
+		t.Errorf("selectQuizzesAfter error: expected quiz %d", quizzes[1].Id)
+	}
+
+	_, err = store.SelectQuizzesAfter(quizzes[2].Id, quizFilter{}, 20)
+	if !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("selectQuizzesAfter error: expected ErrQuizNotFound, got %v", err)
+	}
+}
+
+func TestDeleteQuizzes(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	err := store.DeleteQuizzes([]int64{quizzes[0].Id}, testAuthor)
+	if err != nil {
+		t.Fatalf("deleteQuizzes error: %v", err)
+	}
+	_, err = store.GetQuizById(quizzes[0].Id)
+	if !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("deleteQuizzes error: quiz still exists")
+	}
+
+	err = store.DeleteQuizzes([]int64{quizzes[0].Id}, testAuthor)
+	if !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("deleteQuizzes error: expected ErrQuizNotFound, got %v", err)
+	}
+}
+
+func TestScores(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+
+	_, err := store.GetScoreByUserId("test1")
+	if !errors.Is(err, ErrScoreNotFound) {
+		t.Errorf("getScoreByUserId error: expected ErrScoreNotFound, got %v", err)
+	}
+
+	_ = addScore(store.db, "test1", "Username1", 10)
+	_ = addScore(store.db, "test1", "", 6)
+
+	score, err := store.GetScoreByUserId("test1")
+	if err != nil {
+		t.Fatalf("getScoreByUserId error: %v", err)
+	}
+	if score != 16 {
+		t.Errorf("Expected score 16, got %d", score)
+	}
+}
@@ -1,42 +1,432 @@
 package src
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	profilepb "github.com/Cprime50/quiz/profilepb"
 	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// quizSize is the number of questions served by GetQuiz.
+	quizSize = 20
+	// leaderBoardSize is the number of entries streamed by GetLeaderBoard
+	// when the request has no limit, maxLeaderBoardSize caps the limit.
+	leaderBoardSize    = 10
+	maxLeaderBoardSize = 100
+	// sessionTTL is how long a learner has to submit answers to a quiz.
+	sessionTTL = 30 * time.Minute
+	// DefaultTrashRetention is how long deleted quizzes are kept when the
+	// server has no retention set.
+	DefaultTrashRetention = 30 * 24 * time.Hour
 )
 
 type Server struct {
 	pb.UnimplementedQuizServiceServer
+	store  QuizStore
+	logger *slog.Logger
+	// Profiles receives the points learners earn. Without it points are
+	// only queued, see RetryPendingScores.
+	Profiles profilepb.ProfileServiceClient
+	// TrashRetention is how long deleted quizzes can be restored before
+	// PurgeTrash deletes them for good, DefaultTrashRetention when zero.
+	TrashRetention time.Duration
+	// syncs tracks the score awards being sent in the background.
+	syncs sync.WaitGroup
+}
+
+// NewServer returns a Server keeping quizzes in store and logging to
+// logger, slog.Default() when it is nil.
+func NewServer(store QuizStore, logger *slog.Logger) *Server {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Server{store: store, logger: logger}
 }
 
+func (s *Server) GetQuiz(ctx context.Context, req *pb.GetQuizRequest) (*pb.GetQuizResponse, error) {
+	start := time.Now()
+	if req.UserId == "" {
+		s.logger.Error("GetQuiz error: missing user ID")
+		return nil, status.Errorf(codes.InvalidArgument, "userId is required")
+	}
+	if err := validateAnswerFormat(req.Format, req.Direction); err != nil {
+		s.logger.Error("GetQuiz error", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	filter := quizFilter{DeckId: req.DeckId, Tag: strings.TrimSpace(req.Tag)}
+	var quizzes []*pb.Quiz
+	var err error
+	switch req.Mode {
+	case pb.QuizMode_PROGRESS:
+		var progress int64
+		progress, err = s.store.GetScoreByUserId(req.UserId)
+		if err != nil && !errors.Is(err, ErrScoreNotFound) {
+			s.logger.Error("GetQuiz error: failed to get score", "error", err)
+			return nil, status.Errorf(codes.Internal, "failed to get score: %v", err)
+		}
+		quizzes, err = s.store.SelectQuizzesAfter(progress, filter, quizSize)
+	case pb.QuizMode_REVIEW:
+		quizzes, err = s.store.SelectReviewQuizzes(req.UserId, filter, start.UTC(), quizSize)
+	default:
+		s.logger.Error("GetQuiz error: unknown mode", "mode", req.Mode)
+		return nil, status.Errorf(codes.InvalidArgument, "unknown mode: %v", req.Mode)
+	}
+	if err != nil {
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("GetQuiz error: no quizzes left", "user_id", req.UserId)
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("GetQuiz error: failed to get quizzes", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get quizzes: %v", err)
+	}
 
-func GetQuiz() {
+	seed := req.Seed
+	if seed == 0 {
+		seed = newSeed()
+	}
+	// Distractors come from the answers of the same direction, in the
+	// session's deck or tag first and from every quiz when it is too small
+	pools := make(map[pb.Direction]distractorPool)
+	session := &quizSession{
+		UserId:    req.UserId,
+		Seed:      seed,
+		Format:    req.Format,
+		CreatedAt: start.UTC(),
+		ExpiresAt: start.UTC().Add(sessionTTL),
+	}
+	for _, quiz := range quizzes {
+		direction := questionDirection(seed, quiz.Id, req.Direction)
+		answer := expectedAnswer(quiz, direction)
+		if req.Format == pb.AnswerFormat_CHOICE {
+			pool, ok := pools[direction]
+			if !ok {
+				pool, err = s.answerPools(direction, filter)
+				if err != nil {
+					s.logger.Error("GetQuiz error: failed to get answers", "error", err)
+					return nil, status.Errorf(codes.Internal, "failed to get answers: %v", err)
+				}
+				pools[direction] = pool
+			}
+			buildOptions(seed, quiz, direction, pool.session, pool.all)
+		} else {
+			hideAnswer(quiz, direction)
+		}
+		quiz.Direction = direction
+		session.Questions = append(session.Questions, sessionQuestion{QuizId: quiz.Id, Direction: direction, Options: quiz.Options, Answer: answer})
+	}
 
+	if err := s.store.CreateSession(session); err != nil {
+		s.logger.Error("GetQuiz error: failed to create session", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create quiz session: %v", err)
+	}
+	s.logger.Info("GetQuiz successful: sent quizzes in session", "count", len(quizzes), "session_id", session.Id)
+	s.logger.Info("GetQuiz", "time", time.Since(start))
+	return &pb.GetQuizResponse{
+		Quizes:    quizzes,
+		Seed:      seed,
+		SessionId: session.Id,
+		ExpiresAt: session.ExpiresAt.Format(time.RFC3339),
+		Format:    req.Format,
+		Direction: req.Direction,
+	}, nil
 }
 
-func GetLeaderBoard() {
+// distractorPool holds the answers of one direction distractors are drawn
+// from.
+type distractorPool struct {
+	// session has the answers of the session's deck or tag
+	session []string
+	// all has the answers of every quiz, only set when the session is
+	// narrowed to too few quizzes to draw all distractors from
+	all []string
+}
 
+func (s *Server) answerPools(direction pb.Direction, filter quizFilter) (distractorPool, error) {
+	var pool distractorPool
+	var err error
+	pool.session, err = s.store.SelectAnswers(direction, filter)
+	if err != nil {
+		return pool, err
+	}
+	distinct := make(map[string]bool, len(pool.session))
+	for _, answer := range pool.session {
+		distinct[normalizeAnswer(answer)] = true
+	}
+	// The answer of each question is in the pool too
+	if filter != (quizFilter{}) && len(distinct) <= distractorCount {
+		pool.all, err = s.store.SelectAnswers(direction, quizFilter{})
+	}
+	return pool, err
 }
 
-func GetScore() {
+func (s *Server) GetResult(ctx context.Context, req *pb.GetResultRequest) (*pb.GetResultResponse, error) {
+	start := time.Now()
+	if err := validateResult(req); err != nil {
+		s.logger.Error("GetResult error", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "result validation error: %v", err)
+	}
+
+	session, err := s.store.GetSession(req.SessionId)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			s.logger.Error("GetResult error: session not found", "session_id", req.SessionId)
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("GetResult error: failed to get session", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get quiz session: %v", err)
+	}
+	if session.UserId != req.UserId {
+		s.logger.Error("GetResult error: submitted session of another user", "user_id", req.UserId, "session_id", session.Id)
+		return nil, status.Errorf(codes.PermissionDenied, "quiz session belongs to another user")
+	}
+	if session.CompletedAt.Valid {
+		s.logger.Error("GetResult error: session already graded", "session_id", session.Id)
+		return nil, status.Errorf(codes.FailedPrecondition, ErrSessionCompleted.Error())
+	}
+	if start.After(session.ExpiresAt) {
+		s.logger.Error("GetResult error: session expired", "session_id", session.Id)
+		return nil, status.Errorf(codes.FailedPrecondition, ErrSessionExpired.Error())
+	}
 
+	quizIds := make([]int64, len(session.Questions))
+	for i, q := range session.Questions {
+		quizIds[i] = q.QuizId
+	}
+	states, err := s.store.GetReviewStates(session.UserId, quizIds)
+	if err != nil {
+		s.logger.Error("GetResult error: failed to get review states", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get review states: %v", err)
+	}
+	// Quizzes moved to the trash since the session started are graded as
+	// usual, ones purged since then count as wrong and are not scheduled
+	quizzes := make(map[int64]*pb.Quiz, len(quizIds))
+	for _, quizId := range quizIds {
+		quiz, err := s.store.GetQuizWithDeleted(quizId)
+		if err != nil {
+			if errors.Is(err, ErrQuizNotFound) {
+				s.logger.Warn("GetResult: quiz purged since the session started", "quiz_id", quizId, "session_id", session.Id)
+				continue
+			}
+			s.logger.Error("GetResult error: failed to get quiz", "error", err)
+			return nil, status.Errorf(codes.Internal, "failed to get quiz: %v", err)
+		}
+		quizzes[quizId] = quiz
+	}
+	qualities := make(map[int64]int, len(quizIds))
+	for _, quizId := range quizIds {
+		qualities[quizId] = qualitySkipped
+	}
+
+	score := int64(0)
+	results := make([]*pb.QuestionResult, 0, len(req.Quizes))
+	for i, q := range req.Quizes {
+		question, ok := session.question(q.Id)
+		if !ok {
+			s.logger.Error("GetResult error: quiz not issued in session", "quiz_id", q.Id, "session_id", session.Id)
+			return nil, status.Errorf(codes.InvalidArgument, "quiz %d is not part of this session", q.Id)
+		}
+		// An empty answer is a skipped question
+		if session.Format == pb.AnswerFormat_CHOICE && req.Answer[i] != "" && !slices.Contains(question.Options, req.Answer[i]) {
+			s.logger.Error("GetResult error: answer is not an option", "quiz_id", q.Id, "session_id", session.Id)
+			return nil, status.Errorf(codes.InvalidArgument, "answer to quiz %d is not one of its options", q.Id)
+		}
+		quiz, ok := quizzes[q.Id]
+		if !ok {
+			results = append(results, &pb.QuestionResult{QuizId: q.Id})
+			continue
+		}
+		credit, correct := gradeAnswer(session.Format, question, quiz, req.Answer[i])
+		switch {
+		case credit == 1:
+			score++
+			qualities[q.Id] = qualityCorrect
+		case correct:
+			score++
+			qualities[q.Id] = qualityPass
+		default:
+			qualities[q.Id] = qualityWrong
+		}
+		results = append(results, &pb.QuestionResult{
+			QuizId:   q.Id,
+			Correct:  correct,
+			Credit:   credit,
+			Expected: question.expected(quiz),
+		})
+	}
+
+	now := start.UTC()
+	reviews := make([]reviewState, 0, len(quizIds))
+	for _, quizId := range quizIds {
+		if _, ok := quizzes[quizId]; ok {
+			reviews = append(reviews, schedule(states[quizId], qualities[quizId], now))
+		}
+	}
+
+	// Unanswered questions count as wrong, learners move on to the next set
+	// of quizzes once they get 80% of the session right
+	total := int64(len(session.Questions))
+	nextAllowed := score*5 >= total*4
+	points := int64(0)
+	if nextAllowed {
+		points = score
+	}
+	if err := s.store.CompleteSession(session, score, req.Username, points, reviews); err != nil {
+		if errors.Is(err, ErrSessionCompleted) {
+			s.logger.Error("GetResult error: session already graded", "session_id", session.Id)
+			return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+		}
+		s.logger.Error("GetResult error: failed to complete session", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to update score: %v", err)
+	}
+	if points > 0 && s.Profiles != nil {
+		// The result is already saved, a failed sync is retried later
+		s.syncScore(session.Id)
+	}
+
+	s.logger.Info("GetResult successful", "user_id", req.UserId, "score", score, "total", total)
+	s.logger.Info("GetResult", "time", time.Since(start))
+	return &pb.GetResultResponse{
+		Score:       score,
+		NextAllowed: nextAllowed,
+		Total:       total,
+		Results:     results,
+	}, nil
 }
 
-func GetResult() {
+func (s *Server) GetScore(ctx context.Context, req *pb.GetScoreRequest) (*pb.GetScoreResponse, error) {
+	start := time.Now()
 
+	score, err := s.store.GetScoreByUserId(req.UserId)
+	if err != nil && !errors.Is(err, ErrScoreNotFound) {
+		s.logger.Error("GetScore error: failed to get score", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get score: %v", err)
+	}
+	s.logger.Info("GetScore", "time", time.Since(start))
+	return &pb.GetScoreResponse{UserId: req.UserId, Score: score}, nil
 }
 
-func CreateQuiz() {
+func (s *Server) GetLeaderBoard(req *pb.GetLeaderBoardRequest, stream pb.QuizService_GetLeaderBoardServer) error {
+	start := time.Now()
 
+	since, ok := windowStart(req.Window, start)
+	if !ok {
+		s.logger.Error("GetLeaderBoard error: unknown window", "window", req.Window)
+		return status.Errorf(codes.InvalidArgument, "unknown window: %v", req.Window)
+	}
+	limit := int(req.Limit)
+	if limit <= 0 || limit > maxLeaderBoardSize {
+		limit = leaderBoardSize
+	}
+
+	leaderBoard, err := s.store.SelectLeaderBoard(since, req.UserId, limit)
+	if err != nil {
+		if errors.Is(err, ErrScoreNotFound) {
+			s.logger.Error("GetLeaderBoard error: no scores found")
+			return status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("GetLeaderBoard error: failed to get leaderboard", "error", err)
+		return status.Errorf(codes.Internal, "failed to get leaderboard: %s", err)
+	}
+	for _, entry := range leaderBoard {
+		if err := stream.Send(entry); err != nil {
+			s.logger.Error("GetLeaderBoard error: failed to send leaderboard to client", "error", err)
+			return status.Errorf(codes.Internal, "failed to send leaderboard to client: %s", err)
+		}
+	}
+	s.logger.Info("GetLeaderBoard successful: sent entries", "count", len(leaderBoard))
+	s.logger.Info("GetLeaderBoard", "time", time.Since(start))
+	return nil
 }
 
-func UpdateQuiz() {
+func (s *Server) CreateUpdateQuiz(ctx context.Context, req *pb.CreateUpdateQuizRequest) (*pb.Empty, error) {
+	start := time.Now()
+	if len(req.Quizes) == 0 {
+		s.logger.Error("CreateUpdateQuiz error: no quizzes in request")
+		return nil, status.Errorf(codes.InvalidArgument, "no quizzes in request")
+	}
+	for _, quiz := range req.Quizes {
+		if err := validateQuiz(quiz); err != nil {
+			s.logger.Error("CreateUpdateQuiz error", "error", err)
+			return nil, status.Errorf(codes.InvalidArgument, "quiz validation error: %v", err)
+		}
+	}
 
+	err := s.store.SaveQuizzes(req.Quizes, req.AuthorId)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateInTrash) {
+			s.logger.Error("CreateUpdateQuiz error: quiz already exists in the trash")
+			return nil, status.Errorf(codes.FailedPrecondition, "a quiz in the trash already has this content, restore it instead")
+		}
+		if errors.Is(err, ErrDuplicateEntry) {
+			s.logger.Error("CreateUpdateQuiz error: quiz already exists")
+			return nil, status.Errorf(codes.AlreadyExists, "quiz already exists")
+		}
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("CreateUpdateQuiz error: quiz to update not found")
+			return nil, status.Errorf(codes.NotFound, "quiz not found")
+		}
+		s.logger.Error("CreateUpdateQuiz error: saving quizzes failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "error saving quizzes: %v", err)
+	}
+	s.logger.Info("CreateUpdateQuiz successful: saved quizzes", "count", len(req.Quizes))
+	s.logger.Info("CreateUpdateQuiz", "time", time.Since(start))
+	return &pb.Empty{}, nil
 }
 
-func DeleteQuiz() {
+func (s *Server) DeleteQuiz(ctx context.Context, req *pb.DeleteQuizRequest) (*pb.Empty, error) {
+	start := time.Now()
+	if len(req.QuizId) == 0 {
+		s.logger.Error("DeleteQuiz error: no quiz IDs in request")
+		return nil, status.Errorf(codes.InvalidArgument, "no quiz IDs in request")
+	}
 
+	err := s.store.DeleteQuizzes(req.QuizId, req.AuthorId)
+	if err != nil {
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("DeleteQuiz error: quiz not found")
+			return nil, status.Errorf(codes.NotFound, "quiz not found")
+		}
+		s.logger.Error("DeleteQuiz error: failed to delete quizzes", "error", err)
+		return nil, status.Errorf(codes.Internal, "error deleting quizzes: %v", err)
+	}
+	s.logger.Info("DeleteQuiz successful: moved quizzes to the trash", "count", len(req.QuizId))
+	s.logger.Info("DeleteQuiz", "time", time.Since(start))
+	return &pb.Empty{}, nil
 }
 
-func GetAllQuizzes() {
-	
-}
\ No newline at end of file
+func (s *Server) GetAllQuizzes(req *pb.GetAllQuizzesRequest, stream pb.QuizService_GetAllQuizzesServer) error {
+	start := time.Now()
+
+	quizzes, err := s.store.SelectQuizzes(quizFilter{DeckId: req.DeckId, Tag: strings.TrimSpace(req.Tag)})
+	if err != nil {
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("GetAllQuizzes error: quizzes not found")
+			return status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("GetAllQuizzes error: failed to get quizzes", "error", err)
+		return status.Errorf(codes.Internal, "failed to get quizzes: %s", err)
+	}
+	if err := s.store.LoadQuizLabels(quizzes); err != nil {
+		s.logger.Error("GetAllQuizzes error: failed to get decks and tags", "error", err)
+		return status.Errorf(codes.Internal, "failed to get decks and tags: %s", err)
+	}
+	for _, quiz := range quizzes {
+		if err := stream.Send(quiz); err != nil {
+			s.logger.Error("GetAllQuizzes error: failed to send quizzes to client", "error", err)
+			return status.Errorf(codes.Internal, "failed to send quizzes to client: %s", err)
+		}
+	}
+	s.logger.Info("GetAllQuizzes successful: sent quizzes", "count", len(quizzes))
+	s.logger.Info("GetAllQuizzes", "time", time.Since(start))
+	return nil
+}
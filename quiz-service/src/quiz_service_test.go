@@ -0,0 +1,723 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateUpdateQuiz(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+
+	// Test case 1: Create quizzes
+	_, err := s.CreateUpdateQuiz(context.Background(), &pb.CreateUpdateQuizRequest{Quizes: testQuizzes()})
+	if err != nil {
+		t.Fatalf("CreateUpdateQuiz() error = %v", err)
+	}
+
+	// Test case 2: Invalid quiz
+	_, err = s.CreateUpdateQuiz(context.Background(), &pb.CreateUpdateQuizRequest{
+		Quizes: []*pb.Quiz{{Japanese: "猫", Pronounce: "neko"}},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateUpdateQuiz() expected InvalidArgument, got %v", err)
+	}
+
+	// Test case 3: Duplicate quiz
+	_, err = s.CreateUpdateQuiz(context.Background(), &pb.CreateUpdateQuizRequest{Quizes: testQuizzes()[:1]})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("CreateUpdateQuiz() expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestGetQuiz(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	_ = store.SaveQuizzes(testQuizzes(), testAuthor)
+
+	res, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1"})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	if len(res.Quizes) != 3 {
+		t.Fatalf("Expected 3 quizzes, got %d", len(res.Quizes))
+	}
+	for _, quiz := range res.Quizes {
+		if quiz.English != "" {
+			t.Errorf("GetQuiz() leaked the answer for quiz %d", quiz.Id)
+		}
+		if len(quiz.Options) == 0 {
+			t.Errorf("GetQuiz() returned no options for quiz %d", quiz.Id)
+		}
+	}
+
+	_, err = s.GetQuiz(context.Background(), &pb.GetQuizRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetQuiz() expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetResult(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	quiz, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1"})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	if quiz.SessionId == "" {
+		t.Fatalf("GetQuiz() returned no session")
+	}
+
+	// Test case 1: Quiz not issued in the session
+	req := &pb.GetResultRequest{
+		UserId:    "test1",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: 999999}},
+		Answer:    []string{"a"},
+	}
+	_, err = s.GetResult(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetResult() expected InvalidArgument, got %v", err)
+	}
+
+	// Test case 2: Same quiz answered twice
+	req.Quizes = []*pb.Quiz{{Id: quizzes[0].Id}, {Id: quizzes[0].Id}}
+	req.Answer = []string{quizzes[0].English, quizzes[0].English}
+	_, err = s.GetResult(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetResult() expected InvalidArgument, got %v", err)
+	}
+
+	// Test case 3: Session of another user
+	req.UserId = "test2"
+	req.Quizes = []*pb.Quiz{{Id: quizzes[0].Id}}
+	req.Answer = []string{quizzes[0].English}
+	_, err = s.GetResult(context.Background(), req)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("GetResult() expected PermissionDenied, got %v", err)
+	}
+
+	// Test case 4: Only the issued questions are counted
+	req.UserId = "test1"
+	req.Username = "Username1"
+	req.Quizes = []*pb.Quiz{{Id: quizzes[0].Id}, {Id: quizzes[1].Id}, {Id: quizzes[2].Id}}
+	req.Answer = []string{quizzes[0].English, quizzes[1].English, quizzes[2].English}
+	res, err := s.GetResult(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	if res.Score != 3 || res.Total != 3 || !res.NextAllowed {
+		t.Errorf("GetResult() expected 3/3 and next allowed, got %d/%d %v", res.Score, res.Total, res.NextAllowed)
+	}
+	score, _ := s.GetScore(context.Background(), &pb.GetScoreRequest{UserId: "test1"})
+	if score.Score != 3 {
+		t.Errorf("GetScore() expected 3, got %d", score.Score)
+	}
+
+	// Test case 5: Replaying a graded session
+	_, err = s.GetResult(context.Background(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetResult() expected FailedPrecondition, got %v", err)
+	}
+	score, _ = s.GetScore(context.Background(), &pb.GetScoreRequest{UserId: "test1"})
+	if score.Score != 3 {
+		t.Errorf("GetScore() expected 3 after replay, got %d", score.Score)
+	}
+
+	// Test case 6: Unanswered questions count as wrong
+	quiz, _ = s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test3"})
+	req = &pb.GetResultRequest{
+		UserId:    "test3",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}},
+		Answer:    []string{quizzes[0].English},
+	}
+	res, err = s.GetResult(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	if res.Score != 1 || res.Total != 3 || res.NextAllowed {
+		t.Errorf("GetResult() expected 1/3 and not allowed, got %d/%d %v", res.Score, res.Total, res.NextAllowed)
+	}
+
+	// Test case 7: Expired session
+	expired := &quizSession{
+		UserId:    "test1",
+		Seed:      1,
+		CreatedAt: time.Now().Add(-2 * sessionTTL),
+		ExpiresAt: time.Now().Add(-sessionTTL),
+		Questions: []sessionQuestion{{QuizId: quizzes[0].Id, Options: []string{quizzes[0].English}}},
+	}
+	_ = store.CreateSession(expired)
+	req = &pb.GetResultRequest{
+		UserId:    "test1",
+		SessionId: expired.Id,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}},
+		Answer:    []string{quizzes[0].English},
+	}
+	_, err = s.GetResult(context.Background(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetResult() expected FailedPrecondition, got %v", err)
+	}
+
+	// Test case 8: Unknown session
+	req.SessionId = "not_exist"
+	_, err = s.GetResult(context.Background(), req)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetResult() expected NotFound, got %v", err)
+	}
+}
+
+func TestGetResultChoiceOptions(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	quiz, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1"})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+
+	// Test case 1: Answers that were not offered are rejected
+	req := &pb.GetResultRequest{
+		UserId:    "test1",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}},
+		Answer:    []string{"It's a fish."},
+	}
+	_, err = s.GetResult(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetResult() expected InvalidArgument, got %v", err)
+	}
+
+	// Test case 2: The option picked is graded as issued, after the quiz
+	// was edited during the session
+	edited := []*pb.Quiz{{Id: quizzes[0].Id, Japanese: quizzes[0].Japanese, Pronounce: quizzes[0].Pronounce, English: "That's a cat."}}
+	if err := store.SaveQuizzes(edited, testAuthor); err != nil {
+		t.Fatalf("SaveQuizzes() error = %v", err)
+	}
+	req.Answer = []string{quizzes[0].English}
+	res, err := s.GetResult(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	if res.Score != 1 || !res.Results[0].Correct || res.Results[0].Expected != quizzes[0].English {
+		t.Errorf("GetResult() expected the issued answer right, got %v", res.Results)
+	}
+}
+
+func TestGetResultDeletedQuiz(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	quiz, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1"})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	// One quiz is purged and another moved to the trash after being issued
+	_ = store.DeleteQuizzes([]int64{quizzes[1].Id}, testAuthor)
+	_, _ = store.PurgeQuizzes(time.Now().Add(time.Hour))
+	_ = store.DeleteQuizzes([]int64{quizzes[0].Id}, testAuthor)
+
+	req := &pb.GetResultRequest{
+		UserId:    "test1",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}, {Id: quizzes[1].Id}, {Id: quizzes[2].Id}},
+		Answer:    []string{quizzes[0].English, quizzes[1].English, quizzes[2].English},
+	}
+	res, err := s.GetResult(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	if res.Score != 2 || res.Total != 3 {
+		t.Errorf("GetResult() expected 2/3, got %d/%d", res.Score, res.Total)
+	}
+	if !res.Results[0].Correct || res.Results[1].Correct || res.Results[1].Credit != 0 || !res.Results[2].Correct {
+		t.Errorf("GetResult() expected the purged quiz wrong and the others right, got %v", res.Results)
+	}
+	states, _ := store.GetReviewStates("test1", []int64{quizzes[0].Id, quizzes[1].Id})
+	if states[quizzes[0].Id].Repetitions != 1 || states[quizzes[1].Id].Repetitions != 0 {
+		t.Errorf("GetResult() expected only the trashed quiz scheduled, got %+v", states)
+	}
+}
+
+func TestGetResultTyped(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	// Test case 1: Typed readings are sent with only the japanese
+	quiz, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{
+		UserId:    "test1",
+		Format:    pb.AnswerFormat_TYPED,
+		Direction: pb.Direction_JA_READING,
+	})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	for _, q := range quiz.Quizes {
+		if q.Pronounce != "" || len(q.Options) != 0 {
+			t.Errorf("GetQuiz() leaked the reading or options for quiz %d", q.Id)
+		}
+		if q.English != "" || q.Japanese == "" {
+			t.Errorf("GetQuiz() expected only the japanese for quiz %d", q.Id)
+		}
+	}
+
+	// Test case 2: Kana, typos and wrong readings
+	res, err := s.GetResult(context.Background(), &pb.GetResultRequest{
+		UserId:    "test1",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}, {Id: quizzes[1].Id}, {Id: quizzes[2].Id}},
+		Answer:    []string{"ねこです", "inu desy", "kame desu"},
+	})
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	if res.Score != 2 || len(res.Results) != 3 {
+		t.Fatalf("GetResult() expected 2 points and 3 results, got %d and %d", res.Score, len(res.Results))
+	}
+	if r := res.Results[0]; !r.Correct || r.Credit != 1 || r.Expected != quizzes[0].Pronounce {
+		t.Errorf("GetResult() expected full credit for kana, got %v", r)
+	}
+	if r := res.Results[1]; !r.Correct || r.Credit >= 1 {
+		t.Errorf("GetResult() expected partial credit for a typo, got %v", r)
+	}
+	if r := res.Results[2]; r.Correct {
+		t.Errorf("GetResult() expected a wrong answer, got %v", r)
+	}
+	states, _ := store.GetReviewStates("test1", []int64{quizzes[1].Id})
+	if states[quizzes[1].Id].Lapses != 0 || states[quizzes[1].Id].Repetitions != 1 {
+		t.Errorf("a typo should count as a pass, got %+v", states[quizzes[1].Id])
+	}
+
+	// Test case 3: Typed translations ignore case and punctuation
+	quiz, _ = s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test2", Format: pb.AnswerFormat_TYPED})
+	res, err = s.GetResult(context.Background(), &pb.GetResultRequest{
+		UserId:    "test2",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}},
+		Answer:    []string{"its a CAT"},
+	})
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	if res.Score != 1 || res.Results[0].Credit != 1 {
+		t.Errorf("GetResult() expected full credit, got %v", res.Results[0])
+	}
+
+	// Test case 4: Unknown direction
+	_, err = s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1", Direction: pb.Direction(9)})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetQuiz() expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestGetQuizDirections(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	// Test case 1: English to japanese offers japanese options
+	quiz, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1", Direction: pb.Direction_EN_JA})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	japanese := map[string]bool{}
+	for _, q := range quizzes {
+		japanese[q.Japanese] = true
+	}
+	for _, q := range quiz.Quizes {
+		if q.Japanese != "" || q.Pronounce != "" || q.English == "" || q.Direction != pb.Direction_EN_JA {
+			t.Errorf("GetQuiz() expected only the english for quiz %d, got %v", q.Id, q)
+		}
+		for _, option := range q.Options {
+			if !japanese[option] {
+				t.Errorf("GetQuiz() expected japanese options, got %s", option)
+			}
+		}
+	}
+	res, err := s.GetResult(context.Background(), &pb.GetResultRequest{
+		UserId:    "test1",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}},
+		Answer:    []string{quizzes[0].Japanese},
+	})
+	if err != nil || res.Score != 1 {
+		t.Errorf("GetResult() expected 1 point, got %v %v", res, err)
+	}
+
+	// Test case 2: Typed japanese can be answered with the reading
+	quiz, _ = s.GetQuiz(context.Background(), &pb.GetQuizRequest{
+		UserId:    "test2",
+		Format:    pb.AnswerFormat_TYPED,
+		Direction: pb.Direction_EN_JA,
+	})
+	res, err = s.GetResult(context.Background(), &pb.GetResultRequest{
+		UserId:    "test2",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}, {Id: quizzes[1].Id}},
+		Answer:    []string{"ねこです", "犬です"},
+	})
+	if err != nil || res.Score != 2 {
+		t.Errorf("GetResult() expected 2 points, got %v %v", res, err)
+	}
+
+	// Test case 3: Mixed directions are resolved per question from the seed
+	directions := func() []pb.Direction {
+		quiz, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test3", Seed: 42, Direction: pb.Direction_MIXED})
+		if err != nil {
+			t.Fatalf("GetQuiz() error = %v", err)
+		}
+		var got []pb.Direction
+		for _, q := range quiz.Quizes {
+			if q.Direction == pb.Direction_MIXED {
+				t.Errorf("GetQuiz() left quiz %d in MIXED", q.Id)
+			}
+			got = append(got, q.Direction)
+		}
+		return got
+	}
+	if first, second := directions(), directions(); !reflect.DeepEqual(first, second) {
+		t.Errorf("GetQuiz() expected the same directions for a seed, got %v and %v", first, second)
+	}
+}
+
+func TestGetQuizReview(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	// Test case 1: Without any reviews every quiz is new
+	quiz, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1", Mode: pb.QuizMode_REVIEW})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	if len(quiz.Quizes) != 3 {
+		t.Fatalf("Expected 3 quizzes, got %d", len(quiz.Quizes))
+	}
+
+	// Test case 2: Grading schedules the reviews
+	req := &pb.GetResultRequest{
+		UserId:    "test1",
+		SessionId: quiz.SessionId,
+		Quizes:    []*pb.Quiz{{Id: quizzes[0].Id}, {Id: quizzes[1].Id}},
+		Answer:    []string{quizzes[0].English, quizzes[0].English},
+	}
+	_, err = s.GetResult(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	states, _ := store.GetReviewStates("test1", []int64{quizzes[0].Id, quizzes[1].Id, quizzes[2].Id})
+	if states[quizzes[0].Id].Repetitions != 1 || states[quizzes[1].Id].Lapses != 1 || states[quizzes[2].Id].Lapses != 1 {
+		t.Errorf("GetResult() did not update review states: %+v", states)
+	}
+
+	// Test case 3: Due reviews come first, then new quizzes
+	_, _ = store.db.Exec("UPDATE review_states SET due_at = ? WHERE quiz_id = ?", time.Now().UTC().Add(-time.Hour), quizzes[2].Id)
+	_, _ = store.db.Exec("DELETE FROM review_states WHERE quiz_id = ?", quizzes[1].Id)
+	quiz, err = s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1", Mode: pb.QuizMode_REVIEW})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	if len(quiz.Quizes) != 2 || quiz.Quizes[0].Id != quizzes[2].Id || quiz.Quizes[1].Id != quizzes[1].Id {
+		t.Errorf("GetQuiz() expected due quiz %d then new quiz %d, got %v", quizzes[2].Id, quizzes[1].Id, quiz.Quizes)
+	}
+}
+
+func TestDeleteQuiz(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	_, err := s.DeleteQuiz(context.Background(), &pb.DeleteQuizRequest{QuizId: []int64{quizzes[0].Id}})
+	if err != nil {
+		t.Fatalf("DeleteQuiz() error = %v", err)
+	}
+	_, err = s.DeleteQuiz(context.Background(), &pb.DeleteQuizRequest{QuizId: []int64{quizzes[0].Id}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteQuiz() expected NotFound, got %v", err)
+	}
+
+	// Creating a deleted quiz again points to the trash
+	again := &pb.Quiz{Japanese: quizzes[0].Japanese, Pronounce: quizzes[0].Pronounce, English: quizzes[0].English}
+	_, err = s.CreateUpdateQuiz(context.Background(), &pb.CreateUpdateQuizRequest{Quizes: []*pb.Quiz{again}, AuthorId: testAuthor})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CreateUpdateQuiz() expected FailedPrecondition, got %v", err)
+	}
+	res, err := importFile(s, pb.FileFormat_CSV, "japanese,pronounce,english\n"+again.Japanese+","+again.Pronounce+","+again.English+"\n")
+	if err != nil {
+		t.Fatalf("ImportQuizzes() error = %v", err)
+	}
+	if res.Duplicates != 1 || res.Errors[0].DuplicateOf != quizzes[0].Id || res.Errors[0].Error != ErrDuplicateInTrash.Error() {
+		t.Errorf("ImportQuizzes() expected a duplicate of quiz %d in the trash, got %+v", quizzes[0].Id, res)
+	}
+}
+
+// Mocks for testing stream gRPC
+type mockQuizService_GetAllQuizzesServer struct {
+	grpc.ServerStream
+	Results []*pb.Quiz
+}
+
+func (_m *mockQuizService_GetAllQuizzesServer) Send(q *pb.Quiz) error {
+	_m.Results = append(_m.Results, q)
+	return nil
+}
+
+type mockQuizService_GetLeaderBoardServer struct {
+	grpc.ServerStream
+	Results []*pb.LeaderBoard
+}
+
+func (_m *mockQuizService_GetLeaderBoardServer) Send(l *pb.LeaderBoard) error {
+	_m.Results = append(_m.Results, l)
+	return nil
+}
+
+func TestGetAllQuizzes(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	mock := &mockQuizService_GetAllQuizzesServer{}
+	err := s.GetAllQuizzes(&pb.GetAllQuizzesRequest{}, mock)
+	if err != nil {
+		t.Fatalf("GetAllQuizzes returned error: %v", err)
+	}
+	if len(mock.Results) != len(quizzes) {
+		t.Errorf("Expected %d quizzes, got %d", len(quizzes), len(mock.Results))
+	}
+	for i, result := range mock.Results {
+		if result.Id != quizzes[i].Id || result.English != quizzes[i].English {
+			t.Errorf("Quiz at index %d: expected ID %d, got %d", i, quizzes[i].Id, result.Id)
+		}
+	}
+}
+
+func TestGetQuizDeck(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	deck, err := s.CreateUpdateDeck(context.Background(), &pb.Deck{Name: " Pets "})
+	if err != nil {
+		t.Fatalf("CreateUpdateDeck() error = %v", err)
+	}
+	if deck.Name != "Pets" {
+		t.Errorf("CreateUpdateDeck() expected the name trimmed, got %q", deck.Name)
+	}
+	_, err = s.AssignDeck(context.Background(), &pb.AssignDeckRequest{DeckId: deck.Id, QuizId: []int64{quizzes[1].Id}})
+	if err != nil {
+		t.Fatalf("AssignDeck() error = %v", err)
+	}
+	_, err = s.AssignTags(context.Background(), &pb.AssignTagsRequest{QuizId: []int64{quizzes[1].Id}, Tags: []string{" N5"}})
+	if err != nil {
+		t.Fatalf("AssignTags() error = %v", err)
+	}
+
+	// Test case 1: GetQuiz only serves the quizzes of the deck
+	res, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1", DeckId: deck.Id})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	if len(res.Quizes) != 1 || res.Quizes[0].Id != quizzes[1].Id {
+		t.Errorf("GetQuiz() expected quiz %d, got %v", quizzes[1].Id, res.Quizes)
+	}
+
+	// Test case 2: GetAllQuizzes filters by tag and sends the labels
+	mock := &mockQuizService_GetAllQuizzesServer{}
+	err = s.GetAllQuizzes(&pb.GetAllQuizzesRequest{Tag: "n5"}, mock)
+	if err != nil {
+		t.Fatalf("GetAllQuizzes() error = %v", err)
+	}
+	if len(mock.Results) != 1 || len(mock.Results[0].DeckIds) != 1 || len(mock.Results[0].Tags) != 1 {
+		t.Errorf("GetAllQuizzes() expected quiz %d with its deck and tag, got %v", quizzes[1].Id, mock.Results)
+	}
+
+	// Test case 3: Invalid requests
+	_, err = s.CreateUpdateDeck(context.Background(), &pb.Deck{Name: "  "})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CreateUpdateDeck() expected InvalidArgument, got %v", err)
+	}
+	_, err = s.AssignTags(context.Background(), &pb.AssignTagsRequest{QuizId: []int64{quizzes[1].Id}, Tags: []string{""}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AssignTags() expected InvalidArgument, got %v", err)
+	}
+	_, err = s.DeleteDeck(context.Background(), &pb.DeleteDeckRequest{DeckId: 999999})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("DeleteDeck() expected NotFound, got %v", err)
+	}
+}
+
+func TestGetQuizDeckDistractors(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	_ = store.SaveQuizzes(testQuizzes(), testAuthor)
+	inDeck := []*pb.Quiz{
+		{Japanese: "一です。", Pronounce: "Ichi desu.", English: "It's one."},
+		{Japanese: "二です。", Pronounce: "Ni desu.", English: "It's two."},
+		{Japanese: "三です。", Pronounce: "San desu.", English: "It's three."},
+		{Japanese: "四です。", Pronounce: "Yon desu.", English: "It's four."},
+	}
+	_ = store.SaveQuizzes(inDeck, testAuthor)
+	deck := &pb.Deck{Name: "Numbers"}
+	_ = store.SaveDeck(deck)
+	answers := map[string]bool{}
+	for _, q := range inDeck {
+		_ = store.AssignDeck(deck.Id, []int64{q.Id}, false)
+		answers[q.English] = true
+	}
+
+	// Test case 1: Distractors come from the deck
+	res, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1", DeckId: deck.Id})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	for _, quiz := range res.Quizes {
+		for _, option := range quiz.Options {
+			if !answers[option] {
+				t.Errorf("GetQuiz() expected options from the deck, got %v", quiz.Options)
+			}
+		}
+	}
+
+	// Test case 2: A deck too small for all distractors uses every quiz
+	_ = store.AssignDeck(deck.Id, []int64{inDeck[2].Id, inDeck[3].Id}, true)
+	res, err = s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: "test1", DeckId: deck.Id})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	if len(res.Quizes[0].Options) != distractorCount+1 {
+		t.Errorf("GetQuiz() expected %d options, got %v", distractorCount+1, res.Quizes[0].Options)
+	}
+}
+
+func TestGetLeaderBoard(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+
+	mock := &mockQuizService_GetLeaderBoardServer{}
+	err := s.GetLeaderBoard(&pb.GetLeaderBoardRequest{}, mock)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetLeaderBoard expected NotFound, got %v", err)
+	}
+
+	now := time.Now().UTC()
+	_ = recordScoreEvent(store.db, "test1", "session1", 5, now)
+	_ = recordScoreEvent(store.db, "test2", "session2", 15, now.AddDate(0, 0, -8))
+	_ = recordScoreEvent(store.db, "test3", "session3", 10, now)
+
+	// Test case 1: All time with the caller outside the top entries
+	err = s.GetLeaderBoard(&pb.GetLeaderBoardRequest{UserId: "test1", Limit: 2}, mock)
+	if err != nil {
+		t.Fatalf("GetLeaderBoard returned error: %v", err)
+	}
+	if len(mock.Results) != 3 || mock.Results[0].UserId != "test2" || mock.Results[1].UserId != "test3" {
+		t.Fatalf("GetLeaderBoard returned unexpected order: %v", mock.Results)
+	}
+	if me := mock.Results[2]; me.UserId != "test1" || me.Rank != 3 || !me.CurrentUser {
+		t.Errorf("GetLeaderBoard expected test1 at rank 3, got %v", me)
+	}
+
+	// Test case 2: Weekly window leaves out older points
+	mock.Results = nil
+	err = s.GetLeaderBoard(&pb.GetLeaderBoardRequest{Window: pb.LeaderBoardWindow_WEEK}, mock)
+	if err != nil {
+		t.Fatalf("GetLeaderBoard returned error: %v", err)
+	}
+	if len(mock.Results) != 2 || mock.Results[0].UserId != "test3" {
+		t.Errorf("GetLeaderBoard returned unexpected weekly board: %v", mock.Results)
+	}
+}
+
+// fakeStore is a QuizStore for testing handlers without a database. The
+// methods a test does not set panic through the nil embedded interface.
+type fakeStore struct {
+	QuizStore
+	getScore    func(userId string) (int64, error)
+	leaderBoard func(since time.Time, userId string, limit int) ([]*pb.LeaderBoard, error)
+}
+
+func (f *fakeStore) GetScoreByUserId(userId string) (int64, error) {
+	return f.getScore(userId)
+}
+
+func (f *fakeStore) SelectLeaderBoard(since time.Time, userId string, limit int) ([]*pb.LeaderBoard, error) {
+	return f.leaderBoard(since, userId, limit)
+}
+
+func TestHandlersWithFakeStore(t *testing.T) {
+	t.Parallel()
+	var gotLimit int
+	store := &fakeStore{
+		getScore: func(userId string) (int64, error) {
+			switch userId {
+			case "test1":
+				return 16, nil
+			case "broken":
+				return 0, errors.New("connection reset")
+			}
+			return 0, ErrScoreNotFound
+		},
+		leaderBoard: func(since time.Time, userId string, limit int) ([]*pb.LeaderBoard, error) {
+			gotLimit = limit
+			return nil, ErrScoreNotFound
+		},
+	}
+	s := NewServer(store, nil)
+
+	// Test case 1: Scores are passed through, a learner without one has 0
+	for userId, want := range map[string]int64{"test1": 16, "test2": 0} {
+		resp, err := s.GetScore(context.Background(), &pb.GetScoreRequest{UserId: userId})
+		if err != nil || resp.Score != want {
+			t.Errorf("GetScore(%s) expected %d, got %v, %v", userId, want, resp, err)
+		}
+	}
+
+	// Test case 2: Store errors map to status codes
+	_, err := s.GetScore(context.Background(), &pb.GetScoreRequest{UserId: "broken"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("GetScore expected Internal, got %v", err)
+	}
+	err = s.GetLeaderBoard(&pb.GetLeaderBoardRequest{Limit: 1000}, &mockQuizService_GetLeaderBoardServer{})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetLeaderBoard expected NotFound, got %v", err)
+	}
+	if gotLimit != leaderBoardSize {
+		t.Errorf("GetLeaderBoard expected limit %d, got %d", leaderBoardSize, gotLimit)
+	}
+}
@@ -0,0 +1,67 @@
+package src
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Cprime50/quiz/db"
+	"github.com/Cprime50/quiz/quizfile"
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+// QuizStore keeps the quizzes and everything learners do with them.
+type QuizStore interface {
+	GetQuizById(id int64) (*pb.Quiz, error)
+	GetQuizWithDeleted(id int64) (*pb.Quiz, error)
+	SelectQuizzesAfter(progress int64, filter quizFilter, limit int) ([]*pb.Quiz, error)
+	SelectQuizzes(filter quizFilter) ([]*pb.Quiz, error)
+	SelectAnswers(direction pb.Direction, filter quizFilter) ([]string, error)
+	SaveQuizzes(quizzes []*pb.Quiz, authorId string) error
+	DeleteQuizzes(ids []int64, authorId string) error
+	LoadQuizLabels(quizzes []*pb.Quiz) error
+	ImportQuizzes(r *quizfile.Reader, authorId string) (*pb.ImportQuizzesResponse, error)
+
+	SaveDeck(deck *pb.Deck) error
+	DeleteDeck(id int64) error
+	SelectDecks() ([]*pb.Deck, error)
+	AssignDeck(deckId int64, quizIds []int64, remove bool) error
+	SelectTags() ([]*pb.Tag, error)
+	AssignTags(quizIds []int64, tags []string, remove bool) error
+
+	SelectTrash() ([]*pb.Quiz, error)
+	RestoreQuizzes(ids []int64, authorId string) error
+	PurgeQuizzes(before time.Time) (int64, error)
+	SelectRevisions(quizId int64) ([]*pb.QuizRevision, error)
+	RevertQuiz(revisionId int64, authorId string) (int64, error)
+
+	CreateSession(session *quizSession) error
+	GetSession(id string) (*quizSession, error)
+	CompleteSession(session *quizSession, score int64, username string, points int64, reviews []reviewState) error
+	SelectReviewQuizzes(userId string, filter quizFilter, now time.Time, limit int) ([]*pb.Quiz, error)
+	GetReviewStates(userId string, quizIds []int64) (map[int64]reviewState, error)
+	SelectAttempts(userId string) ([]*pb.QuizAttempt, error)
+	SelectUserReviewStates(userId string) ([]*pb.ReviewState, error)
+
+	GetScoreByUserId(userId string) (int64, error)
+	SelectLeaderBoard(since time.Time, userId string, limit int) ([]*pb.LeaderBoard, error)
+	GetPendingScoreAward(sessionId string) (*scoreAward, error)
+	SelectPendingScoreAwards(maxAttempts int64, limit int) ([]*scoreAward, error)
+	MarkScoreAwardDelivered(sessionId string) error
+	MarkScoreAwardFailed(sessionId string, attempts int64, cause error) error
+}
+
+// sqlStore is the QuizStore on SQLite and PostgreSQL. Its queries are
+// written once, with ? placeholders, and db rewrites them for the dialect.
+type sqlStore struct {
+	db *db.DB
+}
+
+// NewQuizStore returns the QuizStore kept in conn, a database of the given
+// dialect.
+func NewQuizStore(conn *sql.DB, dialect db.Dialect) QuizStore {
+	return &sqlStore{db: db.NewDB(conn, dialect)}
+}
+
+func (s *sqlStore) isConstraintError(err error) bool {
+	return s.db.Dialect.IsUniqueViolation(err)
+}
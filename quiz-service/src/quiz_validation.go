@@ -0,0 +1,89 @@
+package src
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"github.com/Cprime50/quiz/utils"
+)
+
+// maxTagLength is the longest tag name, in characters.
+const maxTagLength = 50
+
+func validateQuiz(in *pb.Quiz) error {
+	rules := map[string]string{
+		"Id":        "min=0",
+		"Japanese":  "required,max=500",
+		"Pronounce": "required,max=500",
+		"English":   "required,max=500",
+	}
+
+	err := utils.ValidateStruct[pb.Quiz](rules, pb.Quiz{}, in)
+	if err != nil {
+		return fmt.Errorf("validateQuiz error: %w", err)
+	}
+	return nil
+}
+
+func validateDeck(in *pb.Deck) error {
+	rules := map[string]string{
+		"Id":          "min=0",
+		"Name":        "required,max=100",
+		"Description": "max=500",
+	}
+
+	err := utils.ValidateStruct[pb.Deck](rules, pb.Deck{}, in)
+	if err != nil {
+		return fmt.Errorf("validateDeck error: %w", err)
+	}
+	return nil
+}
+
+// validateTags trims the tags in place and checks their length.
+func validateTags(tags []string) error {
+	if len(tags) == 0 {
+		return fmt.Errorf("no tags in request")
+	}
+	for i, tag := range tags {
+		tags[i] = strings.TrimSpace(tag)
+		if tags[i] == "" {
+			return fmt.Errorf("tag is empty")
+		}
+		if utf8.RuneCountInString(tags[i]) > maxTagLength {
+			return fmt.Errorf("tag %q is longer than %d characters", tags[i], maxTagLength)
+		}
+	}
+	return nil
+}
+
+func validateResult(in *pb.GetResultRequest) error {
+	if in.UserId == "" {
+		return fmt.Errorf("userId is required")
+	}
+	if in.SessionId == "" {
+		return fmt.Errorf("session_id is required")
+	}
+	if len(in.Quizes) != len(in.Answer) {
+		return fmt.Errorf("got %d answers for %d quizzes", len(in.Answer), len(in.Quizes))
+	}
+	answered := make(map[int64]bool, len(in.Quizes))
+	for _, q := range in.Quizes {
+		if answered[q.Id] {
+			return fmt.Errorf("quiz %d answered more than once", q.Id)
+		}
+		answered[q.Id] = true
+	}
+	return nil
+}
+
+func validateAnswerFormat(format pb.AnswerFormat, direction pb.Direction) error {
+	if _, ok := pb.AnswerFormat_name[int32(format)]; !ok {
+		return fmt.Errorf("unknown answer format: %v", format)
+	}
+	if _, ok := pb.Direction_name[int32(direction)]; !ok {
+		return fmt.Errorf("unknown direction: %v", direction)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package src
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// defaultEase is the SM-2 ease factor of a card never reviewed before.
+	defaultEase = 2.5
+	// minEase keeps hard cards from being scheduled every single day forever.
+	minEase = 1.3
+)
+
+// Review qualities on the SM-2 0-5 scale. Anything below qualityPass is a
+// lapse and restarts the card's intervals.
+const (
+	qualitySkipped = 0
+	qualityWrong   = 1
+	qualityPass    = 3
+	qualityCorrect = 4
+)
+
+// reviewState is where a learner is with one quiz in the spaced repetition
+// schedule.
+type reviewState struct {
+	UserId       string
+	QuizId       int64
+	Ease         float64
+	IntervalDays int64
+	Repetitions  int64
+	Lapses       int64
+	DueAt        time.Time
+	ReviewedAt   time.Time
+}
+
+func newReviewState(userId string, quizId int64) reviewState {
+	return reviewState{UserId: userId, QuizId: quizId, Ease: defaultEase}
+}
+
+// schedule applies one review of the given quality to state following SM-2
+// and returns the updated state.
+func schedule(state reviewState, quality int, now time.Time) reviewState {
+	if quality >= qualityPass {
+		switch state.Repetitions {
+		case 0:
+			state.IntervalDays = 1
+		case 1:
+			state.IntervalDays = 6
+		default:
+			state.IntervalDays = int64(math.Round(float64(state.IntervalDays) * state.Ease))
+		}
+		state.Repetitions++
+	} else {
+		state.Repetitions = 0
+		state.IntervalDays = 1
+		state.Lapses++
+	}
+
+	miss := float64(5 - quality)
+	state.Ease += 0.1 - miss*(0.08+miss*0.02)
+	if state.Ease < minEase {
+		state.Ease = minEase
+	}
+
+	state.ReviewedAt = now
+	state.DueAt = now.AddDate(0, 0, int(state.IntervalDays))
+	return state
+}
@@ -0,0 +1,121 @@
+package src
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+// SelectReviewQuizzes returns up to limit quizzes matching filter for a
+// review session: the ones due for userId first, oldest due date first, then
+// quizzes the learner has never been graded on.
+func (s *sqlStore) SelectReviewQuizzes(userId string, filter quizFilter, now time.Time, limit int) ([]*pb.Quiz, error) {
+	cond, filterArgs := filter.conditions()
+	args := append([]any{userId, now}, filterArgs...)
+	rows, err := s.db.Query(`
+        SELECT `+quizColumns+` FROM quiz
+        JOIN review_states ON review_states.quiz_id = quiz.id AND review_states.user_id = ?
+        WHERE review_states.due_at <= ? AND quiz.deleted IS NULL`+cond+`
+        ORDER BY review_states.due_at, quiz.id
+        LIMIT ?`,
+		append(args, limit)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	quizzes, err := scanQuizzes(rows)
+	if err != nil && !errors.Is(err, ErrQuizNotFound) {
+		return nil, err
+	}
+	if len(quizzes) == limit {
+		return quizzes, nil
+	}
+
+	rows, err = s.db.Query(`
+        SELECT `+quizColumns+` FROM quiz
+        WHERE quiz.deleted IS NULL AND NOT EXISTS (
+            SELECT 1 FROM review_states WHERE review_states.quiz_id = quiz.id AND review_states.user_id = ?
+        )`+cond+`
+        ORDER BY quiz.id
+        LIMIT ?`,
+		append(append([]any{userId}, filterArgs...), limit-len(quizzes))...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	newQuizzes, err := scanQuizzes(rows)
+	if err != nil && !errors.Is(err, ErrQuizNotFound) {
+		return nil, err
+	}
+	quizzes = append(quizzes, newQuizzes...)
+
+	if len(quizzes) == 0 {
+		return nil, ErrQuizNotFound
+	}
+	return quizzes, nil
+}
+
+// GetReviewStates returns the review state of each of quizIds for userId,
+// quizzes never reviewed get a fresh state.
+func (s *sqlStore) GetReviewStates(userId string, quizIds []int64) (map[int64]reviewState, error) {
+	states := make(map[int64]reviewState, len(quizIds))
+	for _, quizId := range quizIds {
+		states[quizId] = newReviewState(userId, quizId)
+	}
+
+	if len(quizIds) == 0 {
+		return states, nil
+	}
+
+	args := []any{userId}
+	placeholders := strings.Repeat(", ?", len(quizIds))[2:]
+	for _, quizId := range quizIds {
+		args = append(args, quizId)
+	}
+	rows, err := s.db.Query("SELECT quiz_id, ease, interval_days, repetitions, lapses, due_at, reviewed_at FROM review_states WHERE user_id = ? AND quiz_id IN ("+placeholders+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		state := reviewState{UserId: userId}
+		if err := rows.Scan(&state.QuizId, &state.Ease, &state.IntervalDays, &state.Repetitions, &state.Lapses, &state.DueAt, &state.ReviewedAt); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		states[state.QuizId] = state
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return states, nil
+}
+
+func saveReviewState(ex execer, state reviewState) error {
+	_, err := ex.Exec(`
+        INSERT INTO review_states (user_id, quiz_id, ease, interval_days, repetitions, lapses, due_at, reviewed_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+        ON CONFLICT (user_id, quiz_id) DO UPDATE SET
+            ease = excluded.ease,
+            interval_days = excluded.interval_days,
+            repetitions = excluded.repetitions,
+            lapses = excluded.lapses,
+            due_at = excluded.due_at,
+            reviewed_at = excluded.reviewed_at`,
+		state.UserId,
+		state.QuizId,
+		state.Ease,
+		state.IntervalDays,
+		state.Repetitions,
+		state.Lapses,
+		state.DueAt,
+		state.ReviewedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("error saving review state: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package src
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := newReviewState("test1", 1)
+
+	// Test case 1: Correct answers grow the interval 1, 6, then by ease
+	state = schedule(state, qualityCorrect, now)
+	if state.IntervalDays != 1 || state.Repetitions != 1 || !state.DueAt.Equal(now.AddDate(0, 0, 1)) {
+		t.Errorf("first review: got interval %d, repetitions %d, due %v", state.IntervalDays, state.Repetitions, state.DueAt)
+	}
+	state = schedule(state, qualityCorrect, now)
+	if state.IntervalDays != 6 {
+		t.Errorf("second review: expected interval 6, got %d", state.IntervalDays)
+	}
+	state = schedule(state, qualityCorrect, now)
+	if state.IntervalDays != 15 {
+		t.Errorf("third review: expected interval 15, got %d", state.IntervalDays)
+	}
+	if state.Ease != defaultEase {
+		t.Errorf("quality 4 should keep the ease, got %v", state.Ease)
+	}
+
+	// Test case 2: A lapse restarts the card and lowers the ease
+	state = schedule(state, qualityWrong, now)
+	if state.IntervalDays != 1 || state.Repetitions != 0 || state.Lapses != 1 {
+		t.Errorf("lapse: got interval %d, repetitions %d, lapses %d", state.IntervalDays, state.Repetitions, state.Lapses)
+	}
+	if state.Ease >= defaultEase {
+		t.Errorf("lapse should lower the ease, got %v", state.Ease)
+	}
+
+	// Test case 3: The ease never goes under the minimum
+	for i := 0; i < 20; i++ {
+		state = schedule(state, qualitySkipped, now)
+	}
+	if state.Ease != minEase {
+		t.Errorf("expected ease %v, got %v", minEase, state.Ease)
+	}
+}
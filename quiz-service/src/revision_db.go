@@ -0,0 +1,186 @@
+package src
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Cprime50/quiz/db"
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrQuizInTrash      = errors.New("quiz is in the trash")
+)
+
+const revisionColumns = `id, quiz_id, action, author_id, created_at,
+    before_japanese, before_pronounce, before_english,
+    after_japanese, after_pronounce, after_english`
+
+func quizContent(q *pb.Quiz) *pb.QuizContent {
+	return &pb.QuizContent{Japanese: q.Japanese, Pronounce: q.Pronounce, English: q.English}
+}
+
+// getQuizContent returns the content of a quiz that is not deleted.
+func getQuizContent(tx *db.Tx, id int64) (*pb.QuizContent, error) {
+	content := &pb.QuizContent{}
+	err := tx.QueryRow("SELECT japanese, pronounce, english FROM quiz WHERE id = ? AND deleted IS NULL", id).
+		Scan(&content.Japanese, &content.Pronounce, &content.English)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrQuizNotFound
+		}
+		return nil, fmt.Errorf("getQuizContent: %w", err)
+	}
+	return content, nil
+}
+
+// recordRevision adds rev to the history of its quiz.
+func recordRevision(ex execer, rev *pb.QuizRevision, at time.Time) error {
+	var before, after [3]any
+	if rev.Before != nil {
+		before = [3]any{rev.Before.Japanese, rev.Before.Pronounce, rev.Before.English}
+	}
+	if rev.After != nil {
+		after = [3]any{rev.After.Japanese, rev.After.Pronounce, rev.After.English}
+	}
+	_, err := ex.Exec(`
+        INSERT INTO quiz_revisions (quiz_id, action, author_id, created_at,
+            before_japanese, before_pronounce, before_english,
+            after_japanese, after_pronounce, after_english)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rev.QuizId,
+		rev.Action.String(),
+		rev.AuthorId,
+		at.UTC(),
+		before[0], before[1], before[2],
+		after[0], after[1], after[2],
+	)
+	if err != nil {
+		return fmt.Errorf("error recording revision: %v", err)
+	}
+	return nil
+}
+
+func scanRevision(row rowScanner) (*pb.QuizRevision, error) {
+	rev := &pb.QuizRevision{}
+	var action string
+	var created time.Time
+	var before, after [3]sql.NullString
+	err := row.Scan(
+		&rev.Id, &rev.QuizId, &action, &rev.AuthorId, &created,
+		&before[0], &before[1], &before[2],
+		&after[0], &after[1], &after[2],
+	)
+	if err != nil {
+		return nil, err
+	}
+	rev.Action = pb.RevisionAction(pb.RevisionAction_value[action])
+	rev.CreatedAt = created.Format(time.RFC3339)
+	if before[0].Valid {
+		rev.Before = &pb.QuizContent{Japanese: before[0].String, Pronounce: before[1].String, English: before[2].String}
+	}
+	if after[0].Valid {
+		rev.After = &pb.QuizContent{Japanese: after[0].String, Pronounce: after[1].String, English: after[2].String}
+	}
+	return rev, nil
+}
+
+// SelectRevisions returns the history of a quiz, latest change first.
+func (s *sqlStore) SelectRevisions(quizId int64) ([]*pb.QuizRevision, error) {
+	rows, err := s.db.Query("SELECT "+revisionColumns+" FROM quiz_revisions WHERE quiz_id = ? ORDER BY id DESC", quizId)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*pb.QuizRevision
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	if len(revisions) == 0 {
+		return nil, ErrRevisionNotFound
+	}
+	return revisions, nil
+}
+
+// RevertQuiz gives a quiz back the content it had right after the revision,
+// or right before it for a deletion, and returns the quiz id. A quiz in the
+// trash is not reverted, ErrQuizInTrash asks to restore it first.
+func (s *sqlStore) RevertQuiz(revisionId int64, authorId string) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	rev, err := scanRevision(tx.QueryRow("SELECT "+revisionColumns+" FROM quiz_revisions WHERE id = ?", revisionId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, ErrRevisionNotFound
+		}
+		return 0, fmt.Errorf("getRevision: %w", err)
+	}
+	target := rev.After
+	if target == nil {
+		target = rev.Before
+	}
+
+	current, err := getQuizContent(tx, rev.QuizId)
+	if err != nil {
+		if errors.Is(err, ErrQuizNotFound) {
+			var trashed bool
+			err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM quiz WHERE id = ? AND deleted IS NOT NULL)", rev.QuizId).Scan(&trashed)
+			if err != nil {
+				return 0, fmt.Errorf("RevertQuiz error: %w", err)
+			}
+			if trashed {
+				return 0, ErrQuizInTrash
+			}
+		}
+		return 0, err
+	}
+	if proto.Equal(current, target) {
+		return rev.QuizId, nil
+	}
+	err = duplicateError(tx, &pb.Quiz{Id: rev.QuizId, Japanese: target.Japanese, Pronounce: target.Pronounce, English: target.English})
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now().UTC()
+	_, err = tx.Exec(
+		"UPDATE quiz SET japanese = ?, pronounce = ?, english = ?, updated = ? WHERE id = ?",
+		target.Japanese,
+		target.Pronounce,
+		target.English,
+		now,
+		rev.QuizId,
+	)
+	if err != nil {
+		if s.isConstraintError(err) {
+			return 0, ErrDuplicateEntry
+		}
+		return 0, fmt.Errorf("RevertQuiz error: %w", err)
+	}
+	err = recordRevision(tx, &pb.QuizRevision{
+		QuizId:   rev.QuizId,
+		Action:   pb.RevisionAction_REVERT,
+		AuthorId: authorId,
+		Before:   current,
+		After:    target,
+	}, now)
+	if err != nil {
+		return 0, err
+	}
+	return rev.QuizId, tx.Commit()
+}
@@ -0,0 +1,147 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRevisions(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes[:1], testAuthor)
+	original := quizContent(quizzes[0])
+
+	// Test case 1: Every change is recorded, latest first
+	quizzes[0].English = "That is a cat."
+	_ = store.SaveQuizzes(quizzes[:1], "editor1")
+	_ = store.SaveQuizzes(quizzes[:1], "editor1")
+	_ = store.DeleteQuizzes([]int64{quizzes[0].Id}, testAuthor)
+	_ = store.RestoreQuizzes([]int64{quizzes[0].Id}, testAuthor)
+
+	revisions, err := store.SelectRevisions(quizzes[0].Id)
+	if err != nil {
+		t.Fatalf("selectRevisions error: %v", err)
+	}
+	actions := []pb.RevisionAction{pb.RevisionAction_RESTORE, pb.RevisionAction_DELETE, pb.RevisionAction_UPDATE, pb.RevisionAction_CREATE}
+	if len(revisions) != len(actions) {
+		t.Fatalf("selectRevisions error: expected %d revisions, got %d", len(actions), len(revisions))
+	}
+	for i, action := range actions {
+		if revisions[i].Action != action {
+			t.Errorf("selectRevisions error: revision %d is %v, want %v", i, revisions[i].Action, action)
+		}
+	}
+	update := revisions[2]
+	if update.AuthorId != "editor1" || update.Before.English != "It's a cat." || update.After.English != "That is a cat." {
+		t.Errorf("selectRevisions error: unexpected update %v", update)
+	}
+	if revisions[3].Before != nil || revisions[1].After != nil {
+		t.Errorf("selectRevisions error: create has a before or delete an after")
+	}
+
+	// Test case 2: Reverting to the creation brings the original back
+	if _, err := store.RevertQuiz(revisions[3].Id, testAuthor); err != nil {
+		t.Fatalf("revertQuiz error: %v", err)
+	}
+	quiz, _ := store.GetQuizById(quizzes[0].Id)
+	if quiz.English != original.English {
+		t.Errorf("revertQuiz error: expected %q, got %q", original.English, quiz.English)
+	}
+	revisions, _ = store.SelectRevisions(quizzes[0].Id)
+	if revisions[0].Action != pb.RevisionAction_REVERT || revisions[0].Before.English != "That is a cat." {
+		t.Errorf("revertQuiz error: revert not recorded, got %v", revisions[0])
+	}
+
+	// Test case 3: A deleted quiz must be restored before reverting
+	_ = store.DeleteQuizzes([]int64{quizzes[0].Id}, testAuthor)
+	if _, err := store.RevertQuiz(update.Id, testAuthor); !errors.Is(err, ErrQuizInTrash) {
+		t.Errorf("revertQuiz error: expected ErrQuizInTrash, got %v", err)
+	}
+	if _, err := store.RevertQuiz(999999, testAuthor); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("revertQuiz error: expected ErrRevisionNotFound, got %v", err)
+	}
+}
+
+func TestRevertQuiz(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	s := NewServer(store, nil)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes[:2], testAuthor)
+	quizzes[0].English = "A cat."
+	_ = store.SaveQuizzes(quizzes[:1], testAuthor)
+
+	mock := &mockQuizService_GetQuizHistoryServer{}
+	if err := s.GetQuizHistory(&pb.GetQuizHistoryRequest{QuizId: quizzes[0].Id}, mock); err != nil {
+		t.Fatalf("GetQuizHistory() error = %v", err)
+	}
+	if len(mock.Results) != 2 {
+		t.Fatalf("GetQuizHistory() expected 2 revisions, got %d", len(mock.Results))
+	}
+
+	// Test case 1: Revert to the content before the update
+	quiz, err := s.RevertQuiz(context.Background(), &pb.RevertQuizRequest{RevisionId: mock.Results[1].Id, AuthorId: testAuthor})
+	if err != nil {
+		t.Fatalf("RevertQuiz() error = %v", err)
+	}
+	if quiz.English != "It's a cat." {
+		t.Errorf("RevertQuiz() expected the original english, got %q", quiz.English)
+	}
+
+	// Test case 2: Content another quiz has taken since can't come back
+	quizzes[0].English = "A cat."
+	_ = store.SaveQuizzes(quizzes[:1], testAuthor)
+	quizzes[1].English = "It's a cat."
+	_ = store.SaveQuizzes(quizzes[1:2], testAuthor)
+	_, err = s.RevertQuiz(context.Background(), &pb.RevertQuizRequest{RevisionId: mock.Results[1].Id})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("RevertQuiz() expected AlreadyExists, got %v", err)
+	}
+
+	// Test case 3: Reverting the deletion of a quiz in the trash asks to
+	// restore it, and reverts to the content before it once restored
+	_ = store.DeleteQuizzes([]int64{quizzes[1].Id}, testAuthor)
+	history := &mockQuizService_GetQuizHistoryServer{}
+	_ = s.GetQuizHistory(&pb.GetQuizHistoryRequest{QuizId: quizzes[1].Id}, history)
+	deletion := history.Results[0]
+	if deletion.Action != pb.RevisionAction_DELETE {
+		t.Fatalf("GetQuizHistory() expected the deletion first, got %v", deletion.Action)
+	}
+	_, err = s.RevertQuiz(context.Background(), &pb.RevertQuizRequest{RevisionId: deletion.Id, AuthorId: testAuthor})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RevertQuiz() expected FailedPrecondition, got %v", err)
+	}
+	_ = store.RestoreQuizzes([]int64{quizzes[1].Id}, testAuthor)
+	quizzes[1].English = "It's a dog."
+	_ = store.SaveQuizzes(quizzes[1:2], testAuthor)
+	quiz, err = s.RevertQuiz(context.Background(), &pb.RevertQuizRequest{RevisionId: deletion.Id, AuthorId: testAuthor})
+	if err != nil {
+		t.Fatalf("RevertQuiz() error = %v", err)
+	}
+	if quiz.English != deletion.Before.English {
+		t.Errorf("RevertQuiz() expected the content before the deletion %q, got %q", deletion.Before.English, quiz.English)
+	}
+
+	// Test case 4: A quiz without history
+	err = s.GetQuizHistory(&pb.GetQuizHistoryRequest{QuizId: 999999}, mock)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetQuizHistory() expected NotFound, got %v", err)
+	}
+}
+
+type mockQuizService_GetQuizHistoryServer struct {
+	grpc.ServerStream
+	Results []*pb.QuizRevision
+}
+
+func (_m *mockQuizService_GetQuizHistoryServer) Send(r *pb.QuizRevision) error {
+	_m.Results = append(_m.Results, r)
+	return nil
+}
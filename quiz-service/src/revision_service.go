@@ -0,0 +1,80 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) GetQuizHistory(req *pb.GetQuizHistoryRequest, stream pb.QuizService_GetQuizHistoryServer) error {
+	start := time.Now()
+	if req.QuizId == 0 {
+		s.logger.Error("GetQuizHistory error: missing quiz ID")
+		return status.Errorf(codes.InvalidArgument, "quiz_id is required")
+	}
+
+	revisions, err := s.store.SelectRevisions(req.QuizId)
+	if err != nil {
+		if errors.Is(err, ErrRevisionNotFound) {
+			s.logger.Error("GetQuizHistory error: no revisions for quiz", "quiz_id", req.QuizId)
+			return status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("GetQuizHistory error: failed to get revisions", "error", err)
+		return status.Errorf(codes.Internal, "failed to get revisions: %s", err)
+	}
+	for _, rev := range revisions {
+		if err := stream.Send(rev); err != nil {
+			s.logger.Error("GetQuizHistory error: failed to send revisions to client", "error", err)
+			return status.Errorf(codes.Internal, "failed to send revisions to client: %s", err)
+		}
+	}
+	s.logger.Info("GetQuizHistory successful: sent revisions of quiz", "count", len(revisions), "quiz_id", req.QuizId)
+	s.logger.Info("GetQuizHistory", "time", time.Since(start))
+	return nil
+}
+
+func (s *Server) RevertQuiz(ctx context.Context, req *pb.RevertQuizRequest) (*pb.Quiz, error) {
+	start := time.Now()
+	if req.RevisionId == 0 {
+		s.logger.Error("RevertQuiz error: missing revision ID")
+		return nil, status.Errorf(codes.InvalidArgument, "revision_id is required")
+	}
+
+	quizId, err := s.store.RevertQuiz(req.RevisionId, req.AuthorId)
+	if err != nil {
+		if errors.Is(err, ErrRevisionNotFound) {
+			s.logger.Error("RevertQuiz error: revision not found")
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, ErrQuizInTrash) {
+			s.logger.Error("RevertQuiz error: quiz of revision is in the trash", "revision_id", req.RevisionId)
+			return nil, status.Errorf(codes.FailedPrecondition, "quiz is in the trash, restore it with RestoreQuiz first")
+		}
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("RevertQuiz error: quiz of revision not found", "revision_id", req.RevisionId)
+			return nil, status.Errorf(codes.NotFound, "quiz not found")
+		}
+		if errors.Is(err, ErrDuplicateInTrash) {
+			s.logger.Error("RevertQuiz error: content of revision taken by a quiz in the trash", "revision_id", req.RevisionId)
+			return nil, status.Errorf(codes.FailedPrecondition, "a quiz in the trash already has this content")
+		}
+		if errors.Is(err, ErrDuplicateEntry) {
+			s.logger.Error("RevertQuiz error: content of revision taken by another quiz", "revision_id", req.RevisionId)
+			return nil, status.Errorf(codes.AlreadyExists, "another quiz already has this content")
+		}
+		s.logger.Error("RevertQuiz error: failed to revert quiz", "error", err)
+		return nil, status.Errorf(codes.Internal, "error reverting quiz: %v", err)
+	}
+	quiz, err := s.store.GetQuizById(quizId)
+	if err != nil {
+		s.logger.Error("RevertQuiz error: failed to get quiz", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to get quiz: %v", err)
+	}
+	s.logger.Info("RevertQuiz successful: quiz reverted to revision", "quiz_id", quizId, "revision_id", req.RevisionId)
+	s.logger.Info("RevertQuiz", "time", time.Since(start))
+	return quiz, nil
+}
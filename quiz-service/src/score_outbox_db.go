@@ -0,0 +1,84 @@
+package src
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrOutboxNotFound = errors.New("score award not found")
+
+// scoreAward is points earned in a quiz session that still have to be added
+// to the learner's profile score. The session id is the idempotency key sent
+// to profile-service.
+type scoreAward struct {
+	SessionId string
+	UserId    string
+	Points    int64
+	Attempts  int64
+}
+
+func queueScoreAward(ex execer, award scoreAward) error {
+	_, err := ex.Exec(
+		"INSERT INTO score_outbox (session_id, user_id, points, created_at) VALUES (?, ?, ?, ?)",
+		award.SessionId,
+		award.UserId,
+		award.Points,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("error queueing score award: %v", err)
+	}
+	return nil
+}
+
+func (s *sqlStore) GetPendingScoreAward(sessionId string) (*scoreAward, error) {
+	award := &scoreAward{}
+	err := s.db.QueryRow("SELECT session_id, user_id, points, attempts FROM score_outbox WHERE session_id = ? AND delivered_at IS NULL", sessionId).
+		Scan(&award.SessionId, &award.UserId, &award.Points, &award.Attempts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOutboxNotFound
+		}
+		return nil, fmt.Errorf("getPendingScoreAward: %w", err)
+	}
+	return award, nil
+}
+
+func (s *sqlStore) SelectPendingScoreAwards(maxAttempts int64, limit int) ([]*scoreAward, error) {
+	rows, err := s.db.Query("SELECT session_id, user_id, points, attempts FROM score_outbox WHERE delivered_at IS NULL AND attempts < ? ORDER BY created_at LIMIT ?", maxAttempts, limit)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var awards []*scoreAward
+	for rows.Next() {
+		award := &scoreAward{}
+		if err := rows.Scan(&award.SessionId, &award.UserId, &award.Points, &award.Attempts); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		awards = append(awards, award)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return awards, nil
+}
+
+func (s *sqlStore) MarkScoreAwardDelivered(sessionId string) error {
+	_, err := s.db.Exec("UPDATE score_outbox SET delivered_at = ?, last_error = '' WHERE session_id = ?", time.Now().UTC(), sessionId)
+	if err != nil {
+		return fmt.Errorf("error updating score award: %v", err)
+	}
+	return nil
+}
+
+func (s *sqlStore) MarkScoreAwardFailed(sessionId string, attempts int64, cause error) error {
+	_, err := s.db.Exec("UPDATE score_outbox SET attempts = attempts + ?, last_error = ? WHERE session_id = ?", attempts, cause.Error(), sessionId)
+	if err != nil {
+		return fmt.Errorf("error updating score award: %v", err)
+	}
+	return nil
+}
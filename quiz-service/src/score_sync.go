@@ -0,0 +1,119 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	profilepb "github.com/Cprime50/quiz/profilepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// syncAttempts is how many times RetryPendingScores sends a score award
+	// to profile-service in one pass.
+	syncAttempts = 3
+	// syncBackoff is the wait before the second attempt, doubled after that.
+	syncBackoff = 200 * time.Millisecond
+	// syncTimeout bounds a single AddScore call.
+	syncTimeout = 2 * time.Second
+	// syncBatchSize is the number of pending awards retried per pass.
+	syncBatchSize = 100
+	// syncMaxAttempts stops retrying awards that keep failing, such as
+	// points for a profile that was deleted.
+	syncMaxAttempts = 30
+)
+
+// syncScore sends the pending award of a session to profile-service in the
+// background, once, so grading does not wait on profile-service. An award
+// that fails stays in the outbox for RetryPendingScores.
+func (s *Server) syncScore(sessionId string) {
+	s.syncs.Add(1)
+	go func() {
+		defer s.syncs.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+		defer cancel()
+
+		award, err := s.store.GetPendingScoreAward(sessionId)
+		if err != nil {
+			if !errors.Is(err, ErrOutboxNotFound) {
+				s.logger.Error("syncScore error: failed to get score award", "session_id", sessionId, "error", err)
+			}
+			return
+		}
+		if err := s.deliverScoreAward(ctx, award, 1); err != nil {
+			s.logger.Error("syncScore error: failed to sync score to profile, left for retry", "session_id", sessionId, "error", err)
+		}
+	}()
+}
+
+// RetryPendingScores sends every award profile-service has not confirmed
+// yet. Since awards are keyed by session, resending one that was in fact
+// applied does not count it twice.
+func (s *Server) RetryPendingScores(ctx context.Context) error {
+	awards, err := s.store.SelectPendingScoreAwards(syncMaxAttempts, syncBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, award := range awards {
+		if err := s.deliverScoreAward(ctx, award, syncAttempts); err != nil {
+			s.logger.Error("RetryPendingScores error", "session_id", award.SessionId, "error", err)
+		}
+	}
+	return nil
+}
+
+// deliverScoreAward sends an award up to maxAttempts times, retrying
+// transient failures.
+func (s *Server) deliverScoreAward(ctx context.Context, award *scoreAward, maxAttempts int64) error {
+	if s.Profiles == nil {
+		return fmt.Errorf("no profile service client configured")
+	}
+
+	var err error
+	attempts := int64(0)
+	backoff := syncBackoff
+retry:
+	for attempts < maxAttempts {
+		if attempts > 0 {
+			select {
+			case <-time.After(backoff):
+				backoff *= 2
+			case <-ctx.Done():
+				err = ctx.Err()
+				break retry
+			}
+		}
+		attempts++
+
+		callCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+		_, err = s.Profiles.AddScore(callCtx, &profilepb.AddScoreRequest{
+			UserId:         award.UserId,
+			Points:         award.Points,
+			IdempotencyKey: award.SessionId,
+		})
+		cancel()
+		if err == nil {
+			s.logger.Info("syncScore successful: added points", "points", award.Points, "user_id", award.UserId)
+			return s.store.MarkScoreAwardDelivered(award.SessionId)
+		}
+		if !isRetryable(err) {
+			break
+		}
+	}
+
+	if markErr := s.store.MarkScoreAwardFailed(award.SessionId, attempts, err); markErr != nil {
+		s.logger.Error("syncScore error", "error", markErr)
+	}
+	return fmt.Errorf("AddScore failed after %d attempts: %w", attempts, err)
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package src
+
+import (
+	"context"
+	"testing"
+
+	profilepb "github.com/Cprime50/quiz/profilepb"
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeProfiles applies AddScore once per idempotency key, like
+// profile-service does, after failing the first failures calls.
+type fakeProfiles struct {
+	profilepb.ProfileServiceClient
+	failures int
+	calls    int
+	keys     map[string]bool
+	score    int64
+}
+
+func (f *fakeProfiles) AddScore(ctx context.Context, in *profilepb.AddScoreRequest, opts ...grpc.CallOption) (*profilepb.AddScoreResponse, error) {
+	f.calls++
+	if f.failures > 0 {
+		f.failures--
+		return nil, status.Errorf(codes.Unavailable, "profile service unavailable")
+	}
+	if f.keys == nil {
+		f.keys = map[string]bool{}
+	}
+	applied := !f.keys[in.IdempotencyKey]
+	if applied {
+		f.keys[in.IdempotencyKey] = true
+		f.score += in.Points
+	}
+	return &profilepb.AddScoreResponse{Score: f.score, Applied: applied}, nil
+}
+
+func passQuiz(t *testing.T, s *Server, userId string, quizzes []*pb.Quiz) string {
+	quiz, err := s.GetQuiz(context.Background(), &pb.GetQuizRequest{UserId: userId})
+	if err != nil {
+		t.Fatalf("GetQuiz() error = %v", err)
+	}
+	req := &pb.GetResultRequest{UserId: userId, SessionId: quiz.SessionId}
+	for _, q := range quizzes {
+		req.Quizes = append(req.Quizes, &pb.Quiz{Id: q.Id})
+		req.Answer = append(req.Answer, q.English)
+	}
+	_, err = s.GetResult(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetResult() error = %v", err)
+	}
+	return quiz.SessionId
+}
+
+func TestSyncScore(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+
+	// Test case 1: Points are sent in the background with the session as key
+	profiles := &fakeProfiles{}
+	s := NewServer(store, nil)
+	s.Profiles = profiles
+	sessionId := passQuiz(t, s, "test1", quizzes)
+	s.syncs.Wait()
+	if profiles.score != 3 || !profiles.keys[sessionId] || profiles.calls != 1 {
+		t.Errorf("expected 3 points in one call, got %d in %d calls", profiles.score, profiles.calls)
+	}
+	if _, err := store.GetPendingScoreAward(sessionId); err != ErrOutboxNotFound {
+		t.Errorf("award should be delivered, got %v", err)
+	}
+
+	// Test case 2: A failed award is tried once, then left to the retrier
+	profiles = &fakeProfiles{failures: 1}
+	s.Profiles = profiles
+	sessionId = passQuiz(t, s, "test2", quizzes)
+	s.syncs.Wait()
+	award, err := store.GetPendingScoreAward(sessionId)
+	if err != nil {
+		t.Fatalf("award should be pending, got %v", err)
+	}
+	if award.Attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", award.Attempts)
+	}
+	if err := s.RetryPendingScores(context.Background()); err != nil {
+		t.Fatalf("RetryPendingScores() error = %v", err)
+	}
+	if err := s.RetryPendingScores(context.Background()); err != nil {
+		t.Fatalf("RetryPendingScores() error = %v", err)
+	}
+	if profiles.score != 3 || profiles.calls != 2 {
+		t.Errorf("expected 3 points after one retry, got %d in %d calls", profiles.score, profiles.calls)
+	}
+
+	// Test case 3: Without a client points are only queued
+	s.Profiles = nil
+	sessionId = passQuiz(t, s, "test3", quizzes)
+	if _, err := store.GetPendingScoreAward(sessionId); err != nil {
+		t.Errorf("award should be pending, got %v", err)
+	}
+}
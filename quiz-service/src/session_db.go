@@ -0,0 +1,188 @@
+package src
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound  = errors.New("quiz session not found")
+	ErrSessionCompleted = errors.New("quiz session already graded")
+	ErrSessionExpired   = errors.New("quiz session expired")
+)
+
+// quizSession records what GetQuiz handed out, so GetResult only grades the
+// questions that were actually issued.
+type quizSession struct {
+	Id          string
+	UserId      string
+	Seed        int64
+	Format      pb.AnswerFormat
+	Questions   []sessionQuestion
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	CompletedAt sql.NullTime
+}
+
+type sessionQuestion struct {
+	QuizId int64
+	// Direction is how this question was asked, never MIXED.
+	Direction pb.Direction
+	Options   []string
+	// Answer is the expected answer when the question was issued
+	Answer string
+}
+
+// expected returns the answer the question is graded against, the one it was
+// issued with or, for sessions started before answers were kept, the current
+// answer of quiz.
+func (q sessionQuestion) expected(quiz *pb.Quiz) string {
+	if q.Answer != "" {
+		return q.Answer
+	}
+	return expectedAnswer(quiz, q.Direction)
+}
+
+// question returns the issued question for quizId.
+func (s *quizSession) question(quizId int64) (sessionQuestion, bool) {
+	for _, q := range s.Questions {
+		if q.QuizId == quizId {
+			return q, true
+		}
+	}
+	return sessionQuestion{}, false
+}
+
+func (s *sqlStore) CreateSession(session *quizSession) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("uuid.NewRandom: %w", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		"INSERT INTO quiz_sessions (id, user_id, seed, format, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?)",
+		id.String(),
+		session.UserId,
+		session.Seed,
+		session.Format,
+		session.CreatedAt,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("CreateSession error: %w", err)
+	}
+	for i, q := range session.Questions {
+		options, err := json.Marshal(q.Options)
+		if err != nil {
+			return fmt.Errorf("json.Marshal: %w", err)
+		}
+		_, err = tx.Exec(
+			"INSERT INTO quiz_session_questions (session_id, quiz_id, position, direction, options, answer) VALUES (?, ?, ?, ?, ?, ?)",
+			id.String(),
+			q.QuizId,
+			i,
+			q.Direction,
+			string(options),
+			q.Answer,
+		)
+		if err != nil {
+			return fmt.Errorf("CreateSession error: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	session.Id = id.String()
+	return nil
+}
+
+func (s *sqlStore) GetSession(id string) (*quizSession, error) {
+	session := &quizSession{}
+	err := s.db.QueryRow("SELECT id, user_id, seed, format, created_at, expires_at, completed_at FROM quiz_sessions WHERE id = ?", id).
+		Scan(&session.Id, &session.UserId, &session.Seed, &session.Format, &session.CreatedAt, &session.ExpiresAt, &session.CompletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("getSession: %w", err)
+	}
+
+	rows, err := s.db.Query("SELECT quiz_id, direction, options, answer FROM quiz_session_questions WHERE session_id = ? ORDER BY position", id)
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var q sessionQuestion
+		var options string
+		if err := rows.Scan(&q.QuizId, &q.Direction, &options, &q.Answer); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+		if err := json.Unmarshal([]byte(options), &q.Options); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		session.Questions = append(session.Questions, q)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+	return session, nil
+}
+
+// CompleteSession marks the session graded, adds points to the learner's
+// score, queues them for profile-service and saves the updated review states
+// in one transaction. Only the first call for a session succeeds, later ones
+// get ErrSessionCompleted.
+func (s *sqlStore) CompleteSession(session *quizSession, score int64, username string, points int64, reviews []reviewState) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		"UPDATE quiz_sessions SET score = ?, completed_at = ? WHERE id = ? AND completed_at IS NULL",
+		score,
+		time.Now().UTC(),
+		session.Id,
+	)
+	if err != nil {
+		return fmt.Errorf("CompleteSession error: %w", err)
+	}
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return ErrSessionCompleted
+	}
+
+	if points > 0 {
+		if err := addScore(tx, session.UserId, username, points); err != nil {
+			return err
+		}
+		award := scoreAward{SessionId: session.Id, UserId: session.UserId, Points: points}
+		if err := queueScoreAward(tx, award); err != nil {
+			return err
+		}
+		if err := recordScoreEvent(tx, session.UserId, session.Id, points, time.Now().UTC()); err != nil {
+			return err
+		}
+	}
+	for _, review := range reviews {
+		if err := saveReviewState(tx, review); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,101 @@
+package src
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+// SelectTrash returns the deleted quizzes, last deleted first.
+func (s *sqlStore) SelectTrash() ([]*pb.Quiz, error) {
+	rows, err := s.db.Query("SELECT " + quizColumns + " FROM quiz WHERE deleted IS NOT NULL ORDER BY deleted DESC, id")
+	if err != nil {
+		return nil, fmt.Errorf("db.Query: %w", err)
+	}
+	return scanQuizzes(rows)
+}
+
+// RestoreQuizzes takes the quizzes out of the trash.
+func (s *sqlStore) RestoreQuizzes(ids []int64, authorId string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	for _, id := range ids {
+		result, err := tx.Exec("UPDATE quiz SET deleted = NULL, updated = ? WHERE id = ? AND deleted IS NOT NULL", now, id)
+		if err != nil {
+			return fmt.Errorf("error restoring quiz: %v", err)
+		}
+		rowsAffected, _ := result.RowsAffected()
+		if rowsAffected == 0 {
+			return ErrQuizNotFound
+		}
+		after, err := getQuizContent(tx, id)
+		if err != nil {
+			return err
+		}
+		err = recordRevision(tx, &pb.QuizRevision{
+			QuizId:   id,
+			Action:   pb.RevisionAction_RESTORE,
+			AuthorId: authorId,
+			After:    after,
+		}, now)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// PurgeQuizzes permanently deletes the quizzes deleted before, with their
+// decks, tags and reviews, and returns how many there were. Their history is
+// kept and ends with a PURGE revision.
+func (s *sqlStore) PurgeQuizzes(before time.Time) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	const purged = "SELECT id FROM quiz WHERE deleted IS NOT NULL AND deleted < ?"
+	before = before.UTC()
+	rows, err := tx.Query("SELECT id, japanese, pronounce, english FROM quiz WHERE id IN ("+purged+")", before)
+	if err != nil {
+		return 0, fmt.Errorf("tx.Query: %w", err)
+	}
+	var revisions []*pb.QuizRevision
+	for rows.Next() {
+		rev := &pb.QuizRevision{Action: pb.RevisionAction_PURGE, Before: &pb.QuizContent{}}
+		if err := rows.Scan(&rev.QuizId, &rev.Before.Japanese, &rev.Before.Pronounce, &rev.Before.English); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("rows.Scan: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	now := time.Now().UTC()
+	for _, rev := range revisions {
+		if err := recordRevision(tx, rev, now); err != nil {
+			return 0, err
+		}
+	}
+	for _, table := range []string{"quiz_decks", "quiz_tags", "review_states"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE quiz_id IN ("+purged+")", before); err != nil {
+			return 0, fmt.Errorf("error purging quizzes: %v", err)
+		}
+	}
+	result, err := tx.Exec("DELETE FROM quiz WHERE id IN ("+purged+")", before)
+	if err != nil {
+		return 0, fmt.Errorf("error purging quizzes: %v", err)
+	}
+	count, _ := result.RowsAffected()
+	return count, tx.Commit()
+}
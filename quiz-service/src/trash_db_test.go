@@ -0,0 +1,125 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+)
+
+func TestTrash(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+	deck := &pb.Deck{Name: "Pets"}
+	_ = store.SaveDeck(deck)
+	_ = store.AssignDeck(deck.Id, []int64{quizzes[0].Id, quizzes[1].Id}, false)
+
+	// Test case 1: Deleted quizzes are hidden from every read
+	if err := store.DeleteQuizzes([]int64{quizzes[0].Id}, testAuthor); err != nil {
+		t.Fatalf("deleteQuizzes error: %v", err)
+	}
+	all, _ := store.SelectQuizzes(quizFilter{})
+	if len(all) != 2 {
+		t.Errorf("selectQuizzes error: expected 2 quizzes, got %d", len(all))
+	}
+	answers, _ := store.SelectAnswers(pb.Direction_JA_EN, quizFilter{})
+	for _, answer := range answers {
+		if answer == quizzes[0].English {
+			t.Errorf("selectAnswers error: deleted quiz used as a distractor")
+		}
+	}
+	decks, _ := store.SelectDecks()
+	if decks[0].QuizCount != 1 {
+		t.Errorf("selectDecks error: expected 1 quiz in the deck, got %d", decks[0].QuizCount)
+	}
+	err := store.SaveQuizzes([]*pb.Quiz{{Id: quizzes[0].Id, Japanese: "a", Pronounce: "a", English: "a"}}, testAuthor)
+	if !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("saveQuizzes error: expected ErrQuizNotFound, got %v", err)
+	}
+
+	// Test case 2: The trash lists it with the time it was deleted
+	trash, err := store.SelectTrash()
+	if err != nil {
+		t.Fatalf("selectTrash error: %v", err)
+	}
+	if len(trash) != 1 || trash[0].Id != quizzes[0].Id || trash[0].DeletedAt == "" {
+		t.Errorf("selectTrash error: expected quiz %d, got %v", quizzes[0].Id, trash)
+	}
+
+	// Test case 3: Restoring brings it back in its deck
+	if err := store.RestoreQuizzes([]int64{quizzes[0].Id}, testAuthor); err != nil {
+		t.Fatalf("restoreQuizzes error: %v", err)
+	}
+	inDeck, _ := store.SelectQuizzes(quizFilter{DeckId: deck.Id})
+	if len(inDeck) != 2 {
+		t.Errorf("restoreQuizzes error: expected 2 quizzes in the deck, got %d", len(inDeck))
+	}
+	if err := store.RestoreQuizzes([]int64{quizzes[0].Id}, testAuthor); !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("restoreQuizzes error: expected ErrQuizNotFound, got %v", err)
+	}
+
+	// Test case 4: Only quizzes deleted before the cutoff are purged
+	_ = store.DeleteQuizzes([]int64{quizzes[0].Id, quizzes[1].Id}, testAuthor)
+	purged, err := store.PurgeQuizzes(time.Now().Add(-time.Hour))
+	if err != nil || purged != 0 {
+		t.Errorf("purgeQuizzes error: expected nothing purged, got %d, %v", purged, err)
+	}
+	purged, err = store.PurgeQuizzes(time.Now().Add(time.Hour))
+	if err != nil || purged != 2 {
+		t.Errorf("purgeQuizzes error: expected 2 quizzes purged, got %d, %v", purged, err)
+	}
+	if _, err := store.SelectTrash(); !errors.Is(err, ErrQuizNotFound) {
+		t.Errorf("selectTrash error: expected ErrQuizNotFound, got %v", err)
+	}
+	var links int
+	_ = store.db.QueryRow("SELECT COUNT(*) FROM quiz_decks").Scan(&links)
+	if links != 0 {
+		t.Errorf("purgeQuizzes error: %d deck links left", links)
+	}
+
+	// Test case 5: The history of a purged quiz is kept
+	revisions, err := store.SelectRevisions(quizzes[0].Id)
+	if err != nil {
+		t.Fatalf("selectRevisions error: %v", err)
+	}
+	if revisions[0].Action != pb.RevisionAction_PURGE || revisions[0].Before.Japanese != quizzes[0].Japanese || revisions[0].After != nil {
+		t.Errorf("purgeQuizzes error: expected a PURGE revision first, got %v", revisions[0])
+	}
+	if last := revisions[len(revisions)-1]; last.Action != pb.RevisionAction_CREATE {
+		t.Errorf("purgeQuizzes error: expected the history back to CREATE, got %v", last)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	quizzes := testQuizzes()
+	_ = store.SaveQuizzes(quizzes, testAuthor)
+	_ = store.DeleteQuizzes([]int64{quizzes[0].Id}, testAuthor)
+
+	// Test case 1: Quizzes within the retention period are kept
+	s := NewServer(store, nil)
+	res, err := s.PurgeTrash(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatalf("PurgeTrash() error = %v", err)
+	}
+	if res.Purged != 0 {
+		t.Errorf("PurgeTrash() expected nothing purged, got %d", res.Purged)
+	}
+
+	// Test case 2: A shorter retention period purges them
+	s = NewServer(store, nil)
+	s.TrashRetention = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	res, err = s.PurgeTrash(context.Background(), &pb.Empty{})
+	if err != nil {
+		t.Fatalf("PurgeTrash() error = %v", err)
+	}
+	if res.Purged != 1 {
+		t.Errorf("PurgeTrash() expected 1 quiz purged, got %d", res.Purged)
+	}
+}
@@ -0,0 +1,95 @@
+package src
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Cprime50/quiz/quizpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ListTrash(req *pb.Empty, stream pb.QuizService_ListTrashServer) error {
+	start := time.Now()
+
+	quizzes, err := s.store.SelectTrash()
+	if err != nil {
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("ListTrash error: trash is empty")
+			return status.Errorf(codes.NotFound, err.Error())
+		}
+		s.logger.Error("ListTrash error: failed to get quizzes", "error", err)
+		return status.Errorf(codes.Internal, "failed to get quizzes: %s", err)
+	}
+	for _, quiz := range quizzes {
+		if err := stream.Send(quiz); err != nil {
+			s.logger.Error("ListTrash error: failed to send quizzes to client", "error", err)
+			return status.Errorf(codes.Internal, "failed to send quizzes to client: %s", err)
+		}
+	}
+	s.logger.Info("ListTrash successful: sent quizzes", "count", len(quizzes))
+	s.logger.Info("ListTrash", "time", time.Since(start))
+	return nil
+}
+
+func (s *Server) RestoreQuiz(ctx context.Context, req *pb.RestoreQuizRequest) (*pb.Empty, error) {
+	start := time.Now()
+	if len(req.QuizId) == 0 {
+		s.logger.Error("RestoreQuiz error: no quiz IDs in request")
+		return nil, status.Errorf(codes.InvalidArgument, "no quiz IDs in request")
+	}
+
+	if err := s.store.RestoreQuizzes(req.QuizId, req.AuthorId); err != nil {
+		if errors.Is(err, ErrQuizNotFound) {
+			s.logger.Error("RestoreQuiz error: quiz not found in trash")
+			return nil, status.Errorf(codes.NotFound, "quiz not found in trash")
+		}
+		s.logger.Error("RestoreQuiz error: failed to restore quizzes", "error", err)
+		return nil, status.Errorf(codes.Internal, "error restoring quizzes: %v", err)
+	}
+	s.logger.Info("RestoreQuiz successful: restored quizzes", "count", len(req.QuizId))
+	s.logger.Info("RestoreQuiz", "time", time.Since(start))
+	return &pb.Empty{}, nil
+}
+
+func (s *Server) PurgeTrash(ctx context.Context, req *pb.Empty) (*pb.PurgeTrashResponse, error) {
+	start := time.Now()
+
+	res, err := s.purgeTrash(start)
+	if err != nil {
+		s.logger.Error("PurgeTrash error: failed to purge quizzes", "error", err)
+		return nil, status.Errorf(codes.Internal, "error purging quizzes: %v", err)
+	}
+	s.logger.Info("PurgeTrash successful: purged quizzes", "purged", res.Purged, "before", res.Before)
+	s.logger.Info("PurgeTrash", "time", time.Since(start))
+	return res, nil
+}
+
+// purgeTrash permanently deletes the quizzes in the trash for longer than
+// the retention period.
+func (s *Server) purgeTrash(now time.Time) (*pb.PurgeTrashResponse, error) {
+	retention := s.TrashRetention
+	if retention <= 0 {
+		retention = DefaultTrashRetention
+	}
+	before := now.Add(-retention).UTC()
+	purged, err := s.store.PurgeQuizzes(before)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PurgeTrashResponse{Purged: purged, Before: before.Format(time.RFC3339)}, nil
+}
+
+// PurgeExpiredTrash purges the trash like PurgeTrash, for main to run on a
+// schedule.
+func (s *Server) PurgeExpiredTrash() error {
+	res, err := s.purgeTrash(time.Now())
+	if err != nil {
+		return err
+	}
+	if res.Purged > 0 {
+		s.logger.Info("PurgeExpiredTrash: purged quizzes", "purged", res.Purged, "before", res.Before)
+	}
+	return nil
+}